@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"os"
 
@@ -35,6 +36,11 @@ func main() {
 	cliClient := cli.NewClient(ctx, jobServiceClient)
 
 	err = cliClient.HandleArgs(os.Args)
+	// Commands such as wait exit with the exit code of the job
+	var exitErr *cli.ExitError
+	if errors.As(err, &exitErr) {
+		os.Exit(exitErr.Code)
+	}
 	if err != nil {
 		log.Print(err)
 		os.Exit(1)
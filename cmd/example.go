@@ -3,7 +3,6 @@ package main
 import (
 	"io"
 	"log"
-	"time"
 
 	"github.com/dboslee/job-worker/pkg/core"
 )
@@ -14,7 +13,8 @@ func init() {
 
 // This is an example showing how the core package can be used.
 func main() {
-	store := core.NewJobStore()
+	core.RunInit()
+	store := core.NewMemoryStore()
 	job, err := core.NewJob("test-client-1", "ping", "-c", "5", "8.8.8.8")
 	if err != nil {
 		log.Fatal(err)
@@ -25,26 +25,28 @@ func main() {
 
 	go job.Start()
 
-	// Buffer is nil at first so wait for it to be created
-	timer := time.NewTicker(time.Millisecond * 100)
-
 	r, err := job.OutputBuf.NewReader()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	b := make([]byte, 1024)
+	// Wait for more output until the job is done
+	done := job.Done()
 	for {
-		status := job.Status()
-		n, err := r.Read(b)
-		if err == io.EOF && status > core.Running {
+		changed := job.OutputBuf.Changed()
+		chunk, err := r.Next()
+		if err == io.EOF && done == nil {
 			break
 		} else if err == io.EOF {
-			<-timer.C
+			select {
+			case <-changed:
+			case <-done:
+				done = nil
+			}
 			continue
 		} else if err != nil {
 			log.Fatal(err)
 		}
-		log.Print(string(b[:n]))
+		log.Print(string(chunk.Data))
 	}
 }
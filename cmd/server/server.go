@@ -1,8 +1,10 @@
 package main
 
 import (
+	"flag"
 	"log"
 	"net"
+	"net/http"
 	"os"
 
 	"github.com/dboslee/job-worker/pkg/api"
@@ -14,8 +16,45 @@ import (
 
 // Bootstrap grpc server
 func main() {
-	jobStore := core.NewJobStore()
-	jobService := api.NewJobService(jobStore)
+	// Isolated jobs re-execute the server as their init process
+	core.RunInit()
+
+	configFile := flag.String("config", "", "path to a json config file")
+	flag.Parse()
+
+	config := api.DefaultConfig()
+	if *configFile != "" {
+		var err error
+		config, err = api.LoadConfig(*configFile)
+		if err != nil {
+			log.Print(err)
+			os.Exit(1)
+		}
+	}
+	if err := config.Check(); err != nil {
+		log.Print(err)
+		os.Exit(1)
+	}
+
+	var jobStore core.JobStore = core.NewMemoryStore()
+	if config.StateFile != "" {
+		fileStore, err := core.OpenFileStore(config.StateFile)
+		if err != nil {
+			log.Print(err)
+			os.Exit(1)
+		}
+		defer fileStore.Close()
+		jobStore = fileStore
+	}
+	jobService := api.NewJobService(jobStore, config)
+
+	// Metrics are published with expvar which registers its handler on the default mux
+	if config.MetricsAddr != "" {
+		go func() {
+			log.Printf("serving metrics on %v", config.MetricsAddr)
+			log.Print(http.ListenAndServe(config.MetricsAddr, nil))
+		}()
+	}
 
 	// TODO: Make certs and port configurable through env vars, config file, or cli args
 	tlsCreds, err := auth.LoadServerTLS("certs/server.pem", "certs/server.key", "certs/ca.pem")
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/dboslee/job-worker/pkg/core"
+)
+
+// DefaultWorkspaceDir is where job workspaces are created
+const DefaultWorkspaceDir = "/var/lib/job-worker/workspaces"
+
+// DefaultStateFile is where jobs are persisted
+const DefaultStateFile = "/var/lib/job-worker/jobs.log"
+
+// DefaultOutputDir is where the output of jobs is stored
+const DefaultOutputDir = "/var/lib/job-worker/output"
+
+// Config holds the server side settings of a JobService
+type Config struct {
+	// CgroupParent is the cgroup v2 directory jobs are placed under, cgroups are disabled when empty
+	CgroupParent string `json:"cgroup_parent"`
+	// DefaultLimits are applied to any limit a client leaves unset
+	DefaultLimits *core.ResourceLimits `json:"default_limits"`
+	// DefaultIsolation is used when a client does not select an isolation mode
+	DefaultIsolation core.Isolation `json:"default_isolation"`
+	// Images maps rootfs image names to a directory or tar archive
+	Images map[string]string `json:"images"`
+	// ImageDir is where image archives are extracted
+	ImageDir string `json:"image_dir"`
+	// WorkspaceDir holds the writable workspace of each job run in an image
+	WorkspaceDir string `json:"workspace_dir"`
+	// MaxRunning is the number of jobs that may run at once, zero is unbounded
+	MaxRunning int `json:"max_running"`
+	// MaxQueued is the number of pending jobs accepted once MaxRunning is reached, zero is unbounded
+	MaxQueued int `json:"max_queued"`
+	// ClientWeights are the fair-share weights of clients by id, clients default to a weight of 1
+	ClientWeights map[string]float64 `json:"client_weights"`
+	// DefaultTimeout is used when a client does not set a timeout, zero is no timeout
+	DefaultTimeout Duration `json:"default_timeout"`
+	// MaxTimeout is the longest timeout a client may request, zero is unbounded
+	MaxTimeout Duration `json:"max_timeout"`
+	// StopSignal is sent to a job that times out
+	StopSignal Signal `json:"stop_signal"`
+	// StopGracePeriod is how long a job has to exit after a stop signal before it is escalated
+	StopGracePeriod Duration `json:"stop_grace_period"`
+	// StateFile is where jobs are persisted across restarts, jobs are only kept in memory when empty
+	StateFile string `json:"state_file"`
+	// OutputDir is where the output of each job is stored, a temporary directory is used when empty
+	OutputDir string `json:"output_dir"`
+	// DefaultOutputLimit is used for any part of the output limit a client leaves unset
+	DefaultOutputLimit core.OutputLimit `json:"default_output_limit"`
+	// MaxOutputBytes is the largest output limit a client may request, zero is unbounded
+	MaxOutputBytes int64 `json:"max_output_bytes"`
+	// Retention limits how many finished jobs and how much output are kept
+	Retention RetentionConfig `json:"retention"`
+	// GCInterval is how often jobs exceeding the retention are removed, zero disables collection
+	GCInterval Duration `json:"gc_interval"`
+	// MetricsAddr is the address metrics are served on at /debug/vars, metrics are not served when empty
+	MetricsAddr string `json:"metrics_addr"`
+	// BaseEnv is the environment jobs start with unless they request a clean environment
+	BaseEnv []string `json:"base_env"`
+	// DefaultUser is the user jobs run as when none is requested, empty runs jobs as the server user
+	DefaultUser string `json:"default_user"`
+	// AllowedUsers are the users clients may request to run as, "*" allows any user
+	AllowedUsers []string `json:"allowed_users"`
+	// AllowedDirs restricts working directories to these directories and below, empty allows any
+	AllowedDirs []string `json:"allowed_dirs"`
+}
+
+// RetentionConfig limits how many finished jobs and how much output are kept, zero values are unlimited
+type RetentionConfig struct {
+	// MaxAge is how long a job is kept after it finishes
+	MaxAge Duration `json:"max_age"`
+	// MaxJobs is the number of finished jobs kept
+	MaxJobs int `json:"max_jobs"`
+	// MaxBytes is the total size of job output kept
+	MaxBytes int64 `json:"max_bytes"`
+}
+
+// Duration is a time.Duration read from a json string such as "1m30s"
+type Duration time.Duration
+
+// UnmarshalJSON implements json.Unmarshaler
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*d = 0
+		return nil
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// Signal is a signal read from a json string such as "SIGTERM"
+type Signal syscall.Signal
+
+// UnmarshalJSON implements json.Unmarshaler
+func (sig *Signal) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := core.ParseSignal(s)
+	if err != nil {
+		return err
+	}
+	*sig = Signal(v)
+	return nil
+}
+
+// DefaultConfig returns the config used when no config file is provided
+func DefaultConfig() Config {
+	return Config{
+		CgroupParent:     core.DefaultCgroupParent,
+		DefaultIsolation: core.IsolationNamespaces,
+		ImageDir:         core.DefaultImageDir,
+		WorkspaceDir:     DefaultWorkspaceDir,
+		MaxRunning:       runtime.NumCPU(),
+		MaxQueued:        100,
+		StopSignal:       Signal(syscall.SIGTERM),
+		StopGracePeriod:  Duration(core.DefaultGracePeriod),
+		StateFile:        DefaultStateFile,
+		OutputDir:        DefaultOutputDir,
+		Retention:        RetentionConfig{MaxAge: Duration(time.Hour * 24 * 7)},
+		GCInterval:       Duration(time.Minute),
+		BaseEnv:          []string{"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"},
+	}
+}
+
+// Check verifies the host supports the cgroup and isolation settings so a misconfigured
+// server fails at startup rather than failing every job
+func (c Config) Check() error {
+	if c.CgroupParent != "" {
+		if err := core.CheckCgroupParent(c.CgroupParent); err != nil {
+			return fmt.Errorf("cgroup_parent %v is not usable, set it to \"\" to disable cgroups: %v", c.CgroupParent, err)
+		}
+	}
+	if c.DefaultIsolation == core.IsolationNamespaces {
+		if err := core.CheckNamespaces(); err != nil {
+			return fmt.Errorf("namespace isolation is not supported, set default_isolation to \"none\": %v", err)
+		}
+	}
+	return nil
+}
+
+// LoadConfig reads a json config file on top of the default config
+func LoadConfig(name string) (Config, error) {
+	config := DefaultConfig()
+	f, err := os.Open(name)
+	if err != nil {
+		return config, err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	err = dec.Decode(&config)
+	return config, err
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/dboslee/job-worker/pkg/api/proto"
+	"github.com/dboslee/job-worker/pkg/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultPageSize is the number of jobs listed when a client does not set a page size
+const DefaultPageSize = 100
+
+// MaxPageSize is the most jobs listed at once
+const MaxPageSize = 1000
+
+// invalidPageToken is returned for page tokens that were not issued by List
+var invalidPageToken = status.Error(codes.InvalidArgument, "invalid page token")
+
+// listKey holds the values jobs are sorted by. The page token is the key of
+// the last job on a page so pages stay consistent as jobs are added or removed.
+type listKey struct {
+	Created  int64  `json:"c"`
+	Finished int64  `json:"f"`
+	Command  string `json:"m"`
+	Status   string `json:"s"`
+	ID       string `json:"i"`
+}
+
+// newListKey captures the sort values of a job
+func newListKey(j *core.Job) listKey {
+	key := listKey{
+		Created: j.CreatedAt.UnixNano(),
+		Command: j.Command,
+		Status:  j.Status().String(),
+		ID:      j.ID,
+	}
+	if finished := j.FinishedAt(); !finished.IsZero() {
+		key.Finished = finished.UnixNano()
+	}
+	return key
+}
+
+// less orders keys by the sort field then by id
+func (k listKey) less(other listKey, by proto.ListSort, descending bool) bool {
+	var cmp int
+	switch by {
+	case proto.ListSort_LIST_SORT_FINISHED:
+		cmp = compareInt(k.Finished, other.Finished)
+	case proto.ListSort_LIST_SORT_COMMAND:
+		cmp = strings.Compare(k.Command, other.Command)
+	case proto.ListSort_LIST_SORT_STATUS:
+		cmp = strings.Compare(k.Status, other.Status)
+	default:
+		cmp = compareInt(k.Created, other.Created)
+	}
+	if cmp == 0 {
+		cmp = strings.Compare(k.ID, other.ID)
+	}
+	if descending {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+// compareInt returns -1, 0 or 1 as a is less than, equal to or greater than b
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// encodePageToken returns the page token resuming after key
+func encodePageToken(key listKey) string {
+	b, _ := json.Marshal(key)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// decodePageToken returns the key of the last job of the previous page
+func decodePageToken(token string) (listKey, error) {
+	var key listKey
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return key, invalidPageToken
+	}
+	if err = json.Unmarshal(b, &key); err != nil || key.ID == "" {
+		return key, invalidPageToken
+	}
+	return key, nil
+}
+
+// listMatch reports if a job passes the filters of a ListRequest
+func listMatch(req *proto.ListRequest, j *core.Job) bool {
+	if len(req.GetStatus()) > 0 && !contains(req.GetStatus(), jobStatuses[j.Status()]) {
+		return false
+	}
+	if !strings.Contains(j.Command, req.GetCommand()) {
+		return false
+	}
+	for k, v := range req.GetLabels() {
+		if value, ok := j.Labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	created := j.CreatedAt.UnixNano()
+	if after := req.GetCreatedAfterUnixNano(); after != 0 && created <= after {
+		return false
+	}
+	if before := req.GetCreatedBeforeUnixNano(); before != 0 && created >= before {
+		return false
+	}
+	return true
+}
+
+// contains reports if s is in list
+func contains(list []proto.JobStatus, s proto.JobStatus) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns a page of the jobs of the calling client
+func (js *JobService) List(ctx context.Context, req *proto.ListRequest) (*proto.ListResponse, error) {
+	cID := ctx.Value(KeyClientID)
+	if cID == nil || cID.(string) == "" {
+		return nil, PermissionDenied
+	}
+	size := int(req.GetPageSize())
+	if size < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page size must not be negative")
+	}
+	if size == 0 {
+		size = DefaultPageSize
+	}
+	if size > MaxPageSize {
+		size = MaxPageSize
+	}
+	for _, s := range req.GetStatus() {
+		if _, ok := proto.JobStatus_name[int32(s)]; !ok || s == proto.JobStatus_JOB_STATUS_UNKNOWN {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown status %v", s)
+		}
+	}
+	var after *listKey
+	if req.GetPageToken() != "" {
+		key, err := decodePageToken(req.GetPageToken())
+		if err != nil {
+			return nil, err
+		}
+		after = &key
+	}
+
+	// Keys are taken once so a job changing status while sorting keeps its place
+	type entry struct {
+		job *core.Job
+		key listKey
+	}
+	var entries []entry
+	for _, j := range js.jobStore.ListClient(cID.(string)) {
+		if !listMatch(req, j) {
+			continue
+		}
+		key := newListKey(j)
+		if after != nil && !after.less(key, req.GetSort(), req.GetDescending()) {
+			continue
+		}
+		entries = append(entries, entry{j, key})
+	}
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].key.less(entries[b].key, req.GetSort(), req.GetDescending())
+	})
+
+	resp := &proto.ListResponse{}
+	if len(entries) > size {
+		entries = entries[:size]
+		resp.NextPageToken = encodePageToken(entries[size-1].key)
+	}
+	for _, e := range entries {
+		// The status is reported as it was sorted
+		s, _ := core.ParseJobStatus(e.key.Status)
+		resp.Jobs = append(resp.Jobs, &proto.JobSummary{
+			Id:               e.job.ID,
+			Command:          e.job.Command,
+			Args:             e.job.Args,
+			Status:           jobStatuses[s],
+			ExitCode:         int64(e.job.ExitCode()),
+			Labels:           e.job.Labels,
+			CreatedUnixNano:  e.key.Created,
+			FinishedUnixNano: e.key.Finished,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/dboslee/job-worker/pkg/api/proto"
+	"github.com/dboslee/job-worker/pkg/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// env builds a job environment from the requested variables on top of the base environment.
+// The server environment is never passed to jobs.
+func (js *JobService) env(req *proto.ExecRequest) ([]string, error) {
+	env := []string{}
+	if !req.GetCleanEnv() {
+		env = append(env, js.config.BaseEnv...)
+	}
+	for _, kv := range req.GetEnv() {
+		if i := strings.IndexByte(kv, '='); i <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid environment variable %q", kv)
+		}
+		env = append(env, kv)
+	}
+	return env, nil
+}
+
+// credential resolves the requested user if it is allowed by the policy, nil runs as the server user
+func (js *JobService) credential(name string) (*syscall.Credential, error) {
+	if name == "" {
+		name = js.config.DefaultUser
+	} else if !js.userAllowed(name) {
+		return nil, status.Errorf(codes.PermissionDenied, "not allowed to run as %q", name)
+	}
+	if name == "" {
+		return nil, nil
+	}
+	cred, err := core.LookupCredential(name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown user %q", name)
+	}
+	return cred, nil
+}
+
+// userAllowed reports if the policy allows running as name
+func (js *JobService) userAllowed(name string) bool {
+	if name == js.config.DefaultUser {
+		return true
+	}
+	for _, allowed := range js.config.AllowedUsers {
+		if allowed == "*" || allowed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// workingDir checks the requested working directory is allowed by the policy
+func (js *JobService) workingDir(dir string) (string, error) {
+	if dir == "" {
+		return "", nil
+	}
+	if !filepath.IsAbs(dir) {
+		return "", status.Error(codes.InvalidArgument, "working directory must be absolute")
+	}
+	dir = filepath.Clean(dir)
+	if len(js.config.AllowedDirs) == 0 {
+		return dir, nil
+	}
+	for _, allowed := range js.config.AllowedDirs {
+		allowed = filepath.Clean(allowed)
+		if dir == allowed || strings.HasPrefix(dir, allowed+string(filepath.Separator)) {
+			return dir, nil
+		}
+	}
+	return "", status.Errorf(codes.PermissionDenied, "not allowed to run in %q", dir)
+}
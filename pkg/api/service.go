@@ -2,8 +2,13 @@ package api
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
 	"time"
 
 	"github.com/dboslee/job-worker/pkg/api/proto"
@@ -20,18 +25,99 @@ const KeyClientID key = iota
 // JobNotFound raised when a job is not found
 var JobNotFound = status.Error(codes.NotFound, "job not found")
 
+// readErr is returned when the output of a job can not be read
+var readErr = status.Error(codes.Internal, "failed to read logs")
+
 // PermissionDenied raised when a client is not authorized
 var PermissionDenied = status.Error(codes.PermissionDenied, "permission denied")
 
+// MaxArgsSize is the most bytes the command and args of a job may total
+const MaxArgsSize = 128 * 1024
+
+// MaxLabelsSize is the most bytes the keys and values of the labels of a job may total
+const MaxLabelsSize = 16 * 1024
+
+// jobStatuses maps core job statuses to proto job statuses
+var jobStatuses = map[core.JobStatus]proto.JobStatus{
+	core.Pending:     proto.JobStatus_JOB_STATUS_PENDING,
+	core.Running:     proto.JobStatus_JOB_STATUS_RUNNING,
+	core.Paused:      proto.JobStatus_JOB_STATUS_PAUSED,
+	core.Complete:    proto.JobStatus_JOB_STATUS_COMPLETE,
+	core.Error:       proto.JobStatus_JOB_STATUS_ERROR,
+	core.TimedOut:    proto.JobStatus_JOB_STATUS_TIMED_OUT,
+	core.Lost:        proto.JobStatus_JOB_STATUS_LOST,
+	core.Failed:      proto.JobStatus_JOB_STATUS_FAILED,
+	core.StartFailed: proto.JobStatus_JOB_STATUS_START_FAILED,
+	core.Killed:      proto.JobStatus_JOB_STATUS_KILLED,
+}
+
+// errorCodes maps core error codes to proto error codes
+var errorCodes = map[core.ErrorCode]proto.ErrorCode{
+	core.ErrorCodeInternal:    proto.ErrorCode_ERROR_CODE_INTERNAL,
+	core.ErrorCodeStartFailed: proto.ErrorCode_ERROR_CODE_START_FAILED,
+	core.ErrorCodeExitStatus:  proto.ErrorCode_ERROR_CODE_EXIT_STATUS,
+	core.ErrorCodeSignaled:    proto.ErrorCode_ERROR_CODE_SIGNALED,
+	core.ErrorCodeTimeout:     proto.ErrorCode_ERROR_CODE_TIMEOUT,
+	core.ErrorCodeOutputLimit: proto.ErrorCode_ERROR_CODE_OUTPUT_LIMIT,
+	core.ErrorCodeCancelled:   proto.ErrorCode_ERROR_CODE_CANCELLED,
+	core.ErrorCodeLost:        proto.ErrorCode_ERROR_CODE_LOST,
+}
+
 // JobService implements the grpc server interface
 type JobService struct {
-	jobStore *core.JobStore
+	jobStore  core.JobStore
+	scheduler *core.Scheduler
+	images    *core.ImageStore
+	events    *core.EventBus
+	config    Config
 }
 
 // NewJobService creats a new JobService instance
-func NewJobService(jobStore *core.JobStore) *JobService {
-	return &JobService{
-		jobStore: jobStore,
+func NewJobService(jobStore core.JobStore, config Config) *JobService {
+	js := &JobService{
+		jobStore:  jobStore,
+		scheduler: core.NewScheduler(config.MaxRunning, config.MaxQueued, config.ClientWeights),
+		images:    core.NewImageStore(config.ImageDir, config.Images),
+		events:    core.NewEventBus(core.DefaultEventHistory),
+		config:    config,
+	}
+	js.requeue()
+	if config.GCInterval > 0 {
+		go js.collectGarbage(time.Duration(config.GCInterval))
+	}
+	return js
+}
+
+// requeue submits the pending jobs restored by the store in the order they were created
+func (js *JobService) requeue() {
+	var pending []*core.Job
+	for _, job := range js.jobStore.List() {
+		if job.Status() == core.Pending {
+			pending = append(pending, job)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+	})
+	for _, job := range pending {
+		job.Events = js.events
+		js.scheduler.Resubmit(job)
+	}
+}
+
+// collectGarbage removes jobs exceeding the retention every interval
+func (js *JobService) collectGarbage(interval time.Duration) {
+	retention := core.Retention{
+		MaxAge:   time.Duration(js.config.Retention.MaxAge),
+		MaxJobs:  js.config.Retention.MaxJobs,
+		MaxBytes: js.config.Retention.MaxBytes,
+	}
+	for range time.Tick(interval) {
+		for _, job := range retention.Expired(js.jobStore.List(), time.Now()) {
+			if err := core.RemoveJob(js.jobStore, job); err != nil {
+				log.Printf("unable to remove expired job %v %v", job.ID, err)
+			}
+		}
 	}
 }
 
@@ -54,18 +140,233 @@ func (js *JobService) Exec(ctx context.Context, req *proto.ExecRequest) (resp *p
 	if cID == nil || cID.(string) == "" {
 		return nil, PermissionDenied
 	}
-	job, err := core.NewJob(cID.(string), req.Command, req.Args...)
+	limits := js.limits(req.GetLimits())
+	if err = limits.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	isolation := js.isolation(req.GetIsolation())
+	timeout, err := js.timeout(req.GetTimeoutMs())
+	if err != nil {
+		return nil, err
+	}
+	env, err := js.env(req)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := js.credential(req.GetUser())
+	if err != nil {
+		return nil, err
+	}
+	dir, err := js.workingDir(req.GetWorkingDir())
+	if err != nil {
+		return nil, err
+	}
+	outputLimit, err := js.outputLimit(req.GetOutputLimit())
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := req.GetLabels()[""]; ok {
+		return nil, status.Error(codes.InvalidArgument, "label keys must not be empty")
+	}
+	size := len(req.GetCommand())
+	for _, arg := range req.GetArgs() {
+		size += len(arg)
+	}
+	if size > MaxArgsSize {
+		return nil, status.Errorf(codes.InvalidArgument, "command and args must not exceed %v bytes", MaxArgsSize)
+	}
+	size = 0
+	for k, v := range req.GetLabels() {
+		size += len(k) + len(v)
+	}
+	if size > MaxLabelsSize {
+		return nil, status.Errorf(codes.InvalidArgument, "labels must not exceed %v bytes", MaxLabelsSize)
+	}
+
+	var rootfs string
+	if req.GetImage() != "" {
+		if isolation != core.IsolationNamespaces {
+			return nil, status.Error(codes.InvalidArgument, "images require namespace isolation")
+		}
+		rootfs, err = js.images.Path(req.GetImage())
+		if err != nil {
+			log.Printf("unable to prepare image %v", err)
+			return nil, status.Error(codes.InvalidArgument, "image not available")
+		}
+	}
+
+	job, err := core.NewJobWithOutputDir(cID.(string), js.config.OutputDir, req.Command, req.Args...)
 	if err != nil {
 		return nil, status.Error(codes.Aborted, "failed to create job")
 	}
+	if req.GetTty() {
+		if err = job.OpenTty(); err != nil {
+			log.Printf("error opening tty %v", err)
+			job.Discard()
+			return nil, status.Error(codes.Aborted, "failed to create job")
+		}
+	} else if req.GetStdin() {
+		if err = job.OpenStdin(); err != nil {
+			log.Printf("error opening stdin %v", err)
+			job.Discard()
+			return nil, status.Error(codes.Aborted, "failed to create job")
+		}
+	}
+	job.Cmd.Env = env
+	job.Cmd.Dir = dir
+	job.Cmd.SysProcAttr = &syscall.SysProcAttr{Credential: cred}
+	job.CgroupParent = js.config.CgroupParent
+	job.Limits = limits
+	job.Isolation = isolation
+	job.Priority = int(req.GetPriority())
+	job.Labels = req.GetLabels()
+	job.Timeout = timeout
+	if js.config.StopSignal != 0 {
+		job.StopSignal = syscall.Signal(js.config.StopSignal)
+	}
+	job.GracePeriod = time.Duration(js.config.StopGracePeriod)
+	job.OutputLimit = outputLimit
+	job.Events = js.events
+	if rootfs != "" {
+		job.Image = req.GetImage()
+		job.Rootfs = rootfs
+		job.Workspace = filepath.Join(js.config.WorkspaceDir, job.ID)
+	}
 
-	js.jobStore.Add(job)
-	go job.Start()
+	// The job is stored before it can start so every status change is recorded
+	if err = js.jobStore.Add(job); err != nil {
+		log.Printf("unable to store job %v", err)
+		job.Discard()
+		return nil, status.Error(codes.Internal, "failed to store job")
+	}
+	if err = js.scheduler.Submit(job); err != nil {
+		if delErr := js.jobStore.Delete(job.ID); delErr != nil {
+			log.Printf("unable to delete rejected job %v", delErr)
+		}
+		job.Discard()
+		if err == core.ErrQueueFull {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return nil, status.Error(codes.Internal, "failed to submit job")
+	}
 
 	resp = &proto.ExecResponse{Id: job.ID}
 	return resp, nil
 }
 
+// limits converts requested limits to core limits using the configured defaults for unset values
+func (js *JobService) limits(req *proto.ResourceLimits) *core.ResourceLimits {
+	limits := &core.ResourceLimits{
+		CPUWeight:      req.GetCpuWeight(),
+		CPUMaxQuotaUS:  req.GetCpuMaxQuotaUs(),
+		CPUMaxPeriodUS: req.GetCpuMaxPeriodUs(),
+		MemoryMax:      req.GetMemoryMax(),
+		MemoryHigh:     req.GetMemoryHigh(),
+	}
+	for _, io := range req.GetIo() {
+		limits.IO = append(limits.IO, core.IOLimit{
+			Device:    io.GetDevice(),
+			ReadBPS:   io.GetReadBps(),
+			WriteBPS:  io.GetWriteBps(),
+			ReadIOPS:  io.GetReadIops(),
+			WriteIOPS: io.GetWriteIops(),
+		})
+	}
+
+	def := js.config.DefaultLimits
+	if def == nil {
+		return limits
+	}
+	if limits.CPUWeight == 0 {
+		limits.CPUWeight = def.CPUWeight
+	}
+	if limits.CPUMaxQuotaUS == 0 {
+		limits.CPUMaxQuotaUS = def.CPUMaxQuotaUS
+		limits.CPUMaxPeriodUS = def.CPUMaxPeriodUS
+	}
+	if limits.MemoryMax == 0 {
+		limits.MemoryMax = def.MemoryMax
+	}
+	if limits.MemoryHigh == 0 {
+		limits.MemoryHigh = def.MemoryHigh
+	}
+	if len(limits.IO) == 0 {
+		limits.IO = def.IO
+	}
+	return limits
+}
+
+// isolation converts a requested isolation mode to core falling back to the configured default
+func (js *JobService) isolation(req proto.Isolation) core.Isolation {
+	switch req {
+	case proto.Isolation_ISOLATION_NONE:
+		return core.IsolationNone
+	case proto.Isolation_ISOLATION_NAMESPACES:
+		return core.IsolationNamespaces
+	default:
+		return js.config.DefaultIsolation
+	}
+}
+
+// timeout applies the configured default and maximum to a requested timeout
+func (js *JobService) timeout(ms int64) (time.Duration, error) {
+	if ms < 0 {
+		return 0, status.Error(codes.InvalidArgument, "timeout must not be negative")
+	}
+	timeout := time.Duration(ms) * time.Millisecond
+	max := time.Duration(js.config.MaxTimeout)
+	if timeout == 0 {
+		timeout = time.Duration(js.config.DefaultTimeout)
+	}
+	if max > 0 && timeout == 0 {
+		timeout = max
+	}
+	if max > 0 && timeout > max {
+		return 0, status.Errorf(codes.InvalidArgument, "timeout must not exceed %v", max)
+	}
+	return timeout, nil
+}
+
+// outputLimit applies the configured default and maximum to a requested output limit
+func (js *JobService) outputLimit(req *proto.OutputLimit) (core.OutputLimit, error) {
+	if req.GetMaxBytes() < 0 || req.GetSegments() < 0 {
+		return core.OutputLimit{}, status.Error(codes.InvalidArgument, "output limit must not be negative")
+	}
+	limit := js.config.DefaultOutputLimit
+	if req.GetMaxBytes() > 0 {
+		limit.MaxBytes = req.GetMaxBytes()
+	}
+	if req.GetSegments() > 0 {
+		limit.Segments = int(req.GetSegments())
+	}
+	switch req.GetPolicy() {
+	case proto.OutputPolicy_OUTPUT_POLICY_TRUNCATE:
+		limit.Policy = core.OutputTruncate
+	case proto.OutputPolicy_OUTPUT_POLICY_ROTATE:
+		limit.Policy = core.OutputRotate
+	case proto.OutputPolicy_OUTPUT_POLICY_KILL:
+		limit.Policy = core.OutputKill
+	}
+
+	max := js.config.MaxOutputBytes
+	if max > 0 && limit.MaxBytes == 0 {
+		limit.MaxBytes = max
+	}
+	if max > 0 && limit.MaxBytes > max {
+		return core.OutputLimit{}, status.Errorf(codes.InvalidArgument, "output limit must not exceed %v bytes", max)
+	}
+	if limit.Policy == core.OutputRotate && limit.MaxBytes > 0 {
+		segments := limit.Segments
+		if segments == 0 {
+			segments = core.DefaultOutputSegments
+		}
+		if limit.MaxBytes < int64(segments) {
+			return core.OutputLimit{}, status.Error(codes.InvalidArgument, "output limit must be at least one byte per segment")
+		}
+	}
+	return limit, nil
+}
+
 // Stop handles interupting a job
 func (js *JobService) Stop(ctx context.Context, req *proto.StopRequest) (resp *proto.StopResponse, err error) {
 	job, err := js.getJob(ctx, req.GetId())
@@ -75,18 +376,38 @@ func (js *JobService) Stop(ctx context.Context, req *proto.StopRequest) (resp *p
 
 	errNotRunning := status.Error(codes.FailedPrecondition, "unable to stop job thats not running")
 
-	if job.Status() != core.Running {
+	if job.Status() == core.Pending && js.scheduler.Cancel(job) {
+		return &proto.StopResponse{Success: true}, nil
+	}
+	if s := job.Status(); s != core.Running && s != core.Paused {
 		return nil, errNotRunning
 	}
 
-	err = job.Interrupt()
+	var sig os.Signal
+	if req.GetSignal() != "" {
+		sig, err = core.ParseSignal(req.GetSignal())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if err = checkStopSignal(sig.(syscall.Signal)); err != nil {
+			return nil, err
+		}
+	}
+	if req.GetGracePeriodMs() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "grace period must not be negative")
+	}
+	grace := time.Duration(req.GetGracePeriodMs()) * time.Millisecond
+	if grace == 0 {
+		grace = time.Duration(js.config.StopGracePeriod)
+	}
+
+	err = job.Stop(sig, grace)
 	if err != nil {
-		s := job.Status()
-		if s != core.Running {
+		if s := job.Status(); s != core.Running && s != core.Paused {
 			return nil, errNotRunning
 		}
 
-		log.Printf("error during interrupt %v", err)
+		log.Printf("error during stop %v", err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -94,6 +415,206 @@ func (js *JobService) Stop(ctx context.Context, req *proto.StopRequest) (resp *p
 	return resp, nil
 }
 
+// Signal sends a signal to a running job
+func (js *JobService) Signal(ctx context.Context, req *proto.SignalRequest) (resp *proto.SignalResponse, err error) {
+	job, err := js.getJob(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := core.ParseSignal(req.GetSignal())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err = checkStopSignal(sig); err != nil {
+		return nil, err
+	}
+
+	errNotRunning := status.Error(codes.FailedPrecondition, "unable to signal job thats not running")
+	if job.Status() != core.Running {
+		return nil, errNotRunning
+	}
+	if err = job.Signal(sig); err != nil {
+		if job.Status() != core.Running {
+			return nil, errNotRunning
+		}
+		log.Printf("error during signal %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.SignalResponse{}, nil
+}
+
+// checkStopSignal rejects SIGSTOP and SIGCONT which would stop or continue the init process
+// of a job rather than the job, pause and resume stop and continue every process of a job
+func checkStopSignal(sig syscall.Signal) error {
+	if sig == syscall.SIGSTOP || sig == syscall.SIGCONT {
+		return status.Errorf(codes.InvalidArgument, "%v is not supported, use pause and resume instead", core.SignalName(sig))
+	}
+	return nil
+}
+
+// Pause freezes a running job
+func (js *JobService) Pause(ctx context.Context, req *proto.PauseRequest) (resp *proto.PauseResponse, err error) {
+	job, err := js.getJob(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = job.Pause()
+	if err == core.ErrNotRunning {
+		return nil, status.Error(codes.FailedPrecondition, "unable to pause job thats not running")
+	} else if err != nil {
+		log.Printf("error during pause %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.PauseResponse{}, nil
+}
+
+// Resume thaws a paused job
+func (js *JobService) Resume(ctx context.Context, req *proto.ResumeRequest) (resp *proto.ResumeResponse, err error) {
+	job, err := js.getJob(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = job.Resume()
+	if err == core.ErrNotPaused {
+		return nil, status.Error(codes.FailedPrecondition, "unable to resume job thats not paused")
+	} else if err != nil {
+		log.Printf("error during resume %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.ResumeResponse{}, nil
+}
+
+// WriteStdin handles the grpc client stream of StdinRequests
+func (js *JobService) WriteStdin(serv proto.JobService_WriteStdinServer) error {
+	var job *core.Job
+	var written int64
+	for {
+		req, err := serv.Recv()
+		if err == io.EOF {
+			return serv.SendAndClose(&proto.StdinResponse{BytesWritten: written})
+		} else if err != nil {
+			return err
+		}
+
+		if job == nil {
+			if job, err = js.getJob(serv.Context(), req.GetId()); err != nil {
+				return err
+			}
+		}
+
+		n, err := job.WriteStdin(req.GetData())
+		written += int64(n)
+		if err == core.ErrNoStdin {
+			return status.Error(codes.FailedPrecondition, "job was not started with stdin")
+		} else if err != nil {
+			return status.Errorf(codes.FailedPrecondition, "unable to write stdin %v", err)
+		}
+
+		if req.GetClose() {
+			if err = job.CloseStdin(); err != nil {
+				log.Printf("error closing stdin %v", err)
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+	}
+}
+
+// Attach handles the grpc bidirectional stream of a job terminal
+func (js *JobService) Attach(serv proto.JobService_AttachServer) error {
+	req, err := serv.Recv()
+	if err != nil {
+		return err
+	}
+	job, err := js.getJob(serv.Context(), req.GetId())
+	if err != nil {
+		return err
+	}
+	if !job.Tty() {
+		return status.Error(codes.FailedPrecondition, "job was not started with a tty")
+	}
+
+	r, err := job.OutputBuf.NewReader()
+	if err != nil {
+		log.Printf("error getting reader %v", err)
+		return readErr
+	}
+	defer r.Close()
+	if !req.GetReplay() {
+		if err := r.SeekEnd(); err != nil {
+			log.Printf("error seeking output %v", err)
+			return readErr
+		}
+	}
+
+	// Input is relayed until the client stops sending, output until the job is done
+	ctx, cancel := context.WithCancel(serv.Context())
+	defer cancel()
+	inputErr := make(chan error, 1)
+	go func() {
+		if err := relayInput(job, req, serv); err != nil {
+			inputErr <- err
+			cancel()
+		}
+	}()
+
+	err = followOutput(ctx, job, r, func(chunk core.Chunk) error {
+		return serv.Send(&proto.AttachResponse{Data: chunk.Data})
+	})
+	select {
+	case ierr := <-inputErr:
+		if err == context.Canceled {
+			return ierr
+		}
+	default:
+	}
+	return err
+}
+
+// relayInput writes keystrokes and applies resizes from an attach stream starting with req
+func relayInput(job *core.Job, req *proto.AttachRequest, serv proto.JobService_AttachServer) error {
+	for {
+		if size := req.GetResize(); size != nil {
+			err := job.Resize(core.Winsize{Rows: uint16(size.GetRows()), Cols: uint16(size.GetCols())})
+			if err != nil {
+				log.Printf("error resizing tty %v", err)
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+		if len(req.GetData()) > 0 {
+			if _, err := job.WriteStdin(req.GetData()); err != nil {
+				return status.Errorf(codes.FailedPrecondition, "unable to write to tty %v", err)
+			}
+		}
+
+		var err error
+		if req, err = serv.Recv(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// Delete removes a finished job and its output
+func (js *JobService) Delete(ctx context.Context, req *proto.DeleteRequest) (resp *proto.DeleteResponse, err error) {
+	job, err := js.getJob(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	err = core.RemoveJob(js.jobStore, job)
+	if err == core.ErrNotFinished {
+		return nil, status.Error(codes.FailedPrecondition, "unable to delete job thats not finished")
+	} else if err != nil {
+		log.Printf("error during delete %v", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &proto.DeleteResponse{}, nil
+}
+
 // Status gets the status of a given job
 func (js *JobService) Status(ctx context.Context, req *proto.StatusRequest) (resp *proto.StatusResponse, err error) {
 	job, err := js.getJob(ctx, req.GetId())
@@ -102,32 +623,88 @@ func (js *JobService) Status(ctx context.Context, req *proto.StatusRequest) (res
 	}
 
 	s := job.Status()
+	dropped := job.OutputBuf.Truncated()
 	resp = &proto.StatusResponse{
-		Status:   s.String(),
-		ExitCode: -1,
+		JobStatus:          jobStatuses[s],
+		ExitCode:           -1,
+		Image:              job.Image,
+		OutputTruncated:    dropped > 0,
+		OutputDroppedBytes: dropped,
+		Command:            job.Command,
+		Args:               job.Args,
+		CreatedUnixNano:    job.CreatedAt.UnixNano(),
+		DurationMs:         job.Duration().Milliseconds(),
+	}
+	if started := job.StartedAt(); !started.IsZero() {
+		resp.StartedUnixNano = started.UnixNano()
+	}
+	if cg := job.CgroupStats(); cg != nil {
+		resp.Cgroup = &proto.CgroupStats{
+			CpuUsageUs:         cg.CPUUsage.Microseconds(),
+			CpuUserUs:          cg.CPUUser.Microseconds(),
+			CpuSystemUs:        cg.CPUSystem.Microseconds(),
+			MemoryCurrentBytes: cg.MemoryCurrent,
+			MemoryPeakBytes:    cg.MemoryPeak,
+			IoReadBytes:        cg.IOReadBytes,
+			IoWriteBytes:       cg.IOWriteBytes,
+		}
 	}
-	if s == core.Running || s == core.Pending {
+	if s == core.Pending {
+		resp.QueuePosition = int64(js.scheduler.Position(job))
+	}
+	if !s.Finished() {
 		return resp, nil
 	}
 
 	resp.ExitCode = int64(job.ExitCode())
+	resp.FinishedUnixNano = job.FinishedAt().UnixNano()
+	if sig := job.TermSignal(); sig != 0 {
+		resp.Signal = core.SignalName(sig)
+		resp.SignalNumber = int32(sig)
+	}
+	if usage := job.Usage(); usage != nil {
+		resp.Usage = &proto.ResourceUsage{
+			UserCpuUs:   usage.UserTime.Microseconds(),
+			SystemCpuUs: usage.SystemTime.Microseconds(),
+			MaxRssBytes: usage.MaxRSS,
+		}
+	}
 
-	// TODO: Use a useful error message for the user here
-	jobErr := job.Error()
-	if jobErr != nil {
-		resp.Error = jobErr.Error()
+	if err := job.Error(); err != nil {
+		// Only the message of a JobError is meant for the client
+		jobErr := &core.JobError{Message: "internal error"}
+		errors.As(err, &jobErr)
+		resp.JobError = &proto.JobError{Code: errorCodes[jobErr.Code], Message: jobErr.Message}
 	}
 	return resp, nil
 }
 
+// Wait blocks until a job has finished or the client gives up waiting
+func (js *JobService) Wait(ctx context.Context, req *proto.WaitRequest) (*proto.StatusResponse, error) {
+	job, err := js.getJob(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-job.Done():
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, status.Error(codes.DeadlineExceeded, "job still running at deadline")
+		}
+		return nil, status.Error(codes.Canceled, ctx.Err().Error())
+	}
+	return js.Status(ctx, &proto.StatusRequest{Id: req.GetId()})
+}
+
 // Logs streams the logs of a given job
 func (js *JobService) Logs(req *proto.LogRequest, serv proto.JobService_LogsServer) error {
 	job, err := js.getJob(serv.Context(), req.GetId())
 	if err != nil {
 		return err
 	}
-
-	readErr := status.Error(codes.Internal, "failed to read logs")
+	if req.GetOffset() < 0 || req.GetTail() < 0 {
+		return status.Error(codes.InvalidArgument, "offset and tail must not be negative")
+	}
 
 	r, err := job.OutputBuf.NewReader()
 	if err != nil {
@@ -136,34 +713,79 @@ func (js *JobService) Logs(req *proto.LogRequest, serv proto.JobService_LogsServ
 	}
 	defer r.Close()
 
-	tick := time.NewTicker(time.Millisecond * 100)
-	resp := &proto.LogResponse{}
+	// Start from whichever of offset and tail is later
+	if req.GetTail() > 0 {
+		if err = r.SeekTail(int(req.GetTail())); err != nil {
+			log.Printf("error seeking output %v", err)
+			return readErr
+		}
+	}
+	if req.GetOffset() > r.Offset() {
+		if err = r.SeekOffset(req.GetOffset()); err != nil {
+			log.Printf("error seeking output %v", err)
+			return readErr
+		}
+	}
 
-	// Loop until the job is done running or an error
-	b := make([]byte, 1024*4)
-	for {
-		status := job.Status()
+	send := func(chunk core.Chunk) error {
+		if req.GetSinceUnixNano() > 0 && chunk.Time.UnixNano() < req.GetSinceUnixNano() {
+			return nil
+		}
+		return serv.Send(&proto.LogResponse{
+			Log:          chunk.Data,
+			Stream:       chunk.Stream.String(),
+			TimeUnixNano: chunk.Time.UnixNano(),
+			Offset:       chunk.Offset,
+		})
+	}
+	if req.GetNoFollow() {
+		return readOutput(r, send)
+	}
+	return followOutput(serv.Context(), job, r, send)
+}
 
-		// If there is a context error return
-		if err = serv.Context().Err(); err != nil {
+// readOutput sends every chunk read from r until it has caught up with the job
+func readOutput(r *core.OutputReader, send func(core.Chunk) error) error {
+	for {
+		chunk, err := r.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			log.Printf("error reading output %v", err)
+			return readErr
+		}
+		if err = send(chunk); err != nil {
 			return err
 		}
+	}
+}
 
-		// Read the output until we hit io.EOF and the job has exited
-		n, err := r.Read(b)
-		if err == io.EOF && (status == core.Complete || status == core.Error) {
-			return nil
-		} else if err == io.EOF {
-			<-tick.C
+// followOutput sends every chunk read from r until the job is done running or an error
+func followOutput(ctx context.Context, job *core.Job, r *core.OutputReader, send func(core.Chunk) error) error {
+	done := job.Done()
+	for {
+		// Fetch the notification before reading so a write in between is not missed
+		changed := job.OutputBuf.Changed()
+		chunk, err := r.Next()
+		if err == io.EOF {
+			// Output is complete once the job is done so drain what is left
+			if done == nil {
+				return nil
+			}
+			select {
+			case <-changed:
+			case <-done:
+				done = nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
 			continue
 		} else if err != nil {
-			log.Printf("error reading logs %v", err)
+			log.Printf("error reading output %v", err)
 			return readErr
 		}
-		resp.Log = b[:n]
 
-		err = serv.Send(resp)
-		if err != nil {
+		if err = send(chunk); err != nil {
 			return err
 		}
 	}
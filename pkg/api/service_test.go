@@ -2,7 +2,16 @@ package api_test
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/dboslee/job-worker/pkg/api"
 	"github.com/dboslee/job-worker/pkg/api/proto"
@@ -11,12 +20,46 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+func TestMain(m *testing.M) {
+	core.RunInit()
+	os.Exit(m.Run())
+}
+
 func mockService() *api.JobService {
 	return api.NewJobService(
-		core.NewJobStore(),
+		core.NewMemoryStore(),
+		api.Config{},
 	)
 }
 
+func TestRequeue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.log")
+	store, err := core.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	job, _ := core.NewJob("client1", "echo", "hello")
+	store.Add(job)
+	store.Close()
+
+	// Jobs still pending when the server stopped are submitted again
+	store, err = core.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer store.Close()
+	api.NewJobService(store, api.Config{})
+	restored, _ := store.Get(job.ID)
+	select {
+	case <-restored.Done():
+	case <-time.After(time.Second * 5):
+		t.Fatalf("requeued job did not finish")
+	}
+	if status := restored.Status(); status != core.Complete {
+		t.Errorf("unexpected status got: %v want: %v", status, core.Complete)
+	}
+}
+
 func TestAuthorizedAccess(t *testing.T) {
 	service := mockService()
 	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
@@ -48,3 +91,671 @@ func TestStatusNotfound(t *testing.T) {
 		t.Errorf("expected not found error got: %v", e.Code())
 	}
 }
+
+func TestExecUnknownImage(t *testing.T) {
+	service := api.NewJobService(core.NewMemoryStore(), api.Config{
+		DefaultIsolation: core.IsolationNamespaces,
+	})
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+
+	_, err := service.Exec(ctx, &proto.ExecRequest{Command: "ls", Image: "missing"})
+	if e, _ := status.FromError(err); e.Code() != codes.InvalidArgument {
+		t.Errorf("expected invalid argument got: %v", e.Code())
+	}
+}
+
+func TestExecImage(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("images require root")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("building the image requires go")
+	}
+	// The image only holds a static helper so nothing from the host is visible
+	image := t.TempDir()
+	build := exec.Command("go", "build", "-o", filepath.Join(image, "bin", "imagehelper"), "./testdata/imagehelper")
+	build.Env = append(os.Environ(), "CGO_ENABLED=0")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("unable to build helper %v %s", err, out)
+	}
+
+	workspaces := t.TempDir()
+	service := api.NewJobService(core.NewMemoryStore(), api.Config{
+		DefaultIsolation: core.IsolationNamespaces,
+		Images:           map[string]string{"helper": image},
+		WorkspaceDir:     workspaces,
+	})
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+	resp, err := service.Exec(ctx, &proto.ExecRequest{Command: "/bin/imagehelper", Image: "helper"})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	waitResp, err := service.Wait(ctx, &proto.WaitRequest{Id: resp.GetId()})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	if waitResp.GetJobStatus() != proto.JobStatus_JOB_STATUS_COMPLETE {
+		t.Errorf("expected job to complete got: %v %v", waitResp.GetJobStatus(), waitResp.GetJobError())
+	}
+	statusResp, err := service.Status(ctx, &proto.StatusRequest{Id: resp.GetId()})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	if statusResp.GetImage() != "helper" {
+		t.Errorf("image want: helper got: %q", statusResp.GetImage())
+	}
+
+	stream := &logsStream{ctx: ctx}
+	if err = service.Logs(&proto.LogRequest{Id: resp.GetId()}, stream); err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	var out strings.Builder
+	for _, r := range stream.resps {
+		out.Write(r.GetLog())
+	}
+	if want := "procs: 2\nbase: read only\nworkspace: writable\n"; out.String() != want {
+		t.Errorf("output want: %q got: %q", want, out.String())
+	}
+	if _, err = os.Stat(filepath.Join(image, "base")); err == nil {
+		t.Errorf("expected the image to be unchanged")
+	}
+	b, _ := ioutil.ReadFile(filepath.Join(workspaces, resp.GetId(), "out"))
+	if string(b) != "workspace\n" {
+		t.Errorf("workspace file want: %q got: %q", "workspace\n", b)
+	}
+}
+
+func TestExecTooLarge(t *testing.T) {
+	service := mockService()
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+
+	large := strings.Repeat("a", api.MaxArgsSize)
+	for _, req := range []*proto.ExecRequest{
+		{Command: "echo", Args: []string{large}},
+		{Command: "echo", Labels: map[string]string{"k": large}},
+	} {
+		_, err := service.Exec(ctx, req)
+		if e, _ := status.FromError(err); e.Code() != codes.InvalidArgument {
+			t.Errorf("expected invalid argument got: %v", e.Code())
+		}
+	}
+}
+
+func TestExecQueueFull(t *testing.T) {
+	service := api.NewJobService(core.NewMemoryStore(), api.Config{
+		MaxRunning: 1,
+		MaxQueued:  1,
+	})
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+	req := &proto.ExecRequest{Command: "sleep", Args: []string{"5"}}
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		resp, err := service.Exec(ctx, req)
+		if err != nil {
+			t.Fatalf("expected no error got: %v", err)
+		}
+		ids = append(ids, resp.GetId())
+	}
+	_, err := service.Exec(ctx, req)
+	if e, _ := status.FromError(err); e.Code() != codes.ResourceExhausted {
+		t.Errorf("expected resource exhausted got: %v", e.Code())
+	}
+	// The rejected job is not kept
+	if list, _ := service.List(ctx, &proto.ListRequest{}); len(list.GetJobs()) != len(ids) {
+		t.Errorf("expected %v jobs got: %v", len(ids), len(list.GetJobs()))
+	}
+
+	for resp, _ := service.Status(ctx, &proto.StatusRequest{Id: ids[0]}); resp.GetJobStatus() != proto.JobStatus_JOB_STATUS_RUNNING; {
+		time.Sleep(time.Millisecond * 10)
+		resp, _ = service.Status(ctx, &proto.StatusRequest{Id: ids[0]})
+	}
+	resp, _ := service.Status(ctx, &proto.StatusRequest{Id: ids[1]})
+	if resp.GetQueuePosition() != 1 {
+		t.Errorf("queue position want: 1 got: %v", resp.GetQueuePosition())
+	}
+
+	// Stopping the queued job removes it before the running one is stopped
+	for i := len(ids) - 1; i >= 0; i-- {
+		if _, err := service.Stop(ctx, &proto.StopRequest{Id: ids[i]}); err != nil {
+			t.Errorf("expected no error got: %v", err)
+		}
+	}
+}
+
+func TestExecPolicy(t *testing.T) {
+	service := api.NewJobService(core.NewMemoryStore(), api.Config{
+		AllowedUsers: []string{"nobody"},
+		AllowedDirs:  []string{"/tmp"},
+	})
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+
+	tests := []struct {
+		req  *proto.ExecRequest
+		code codes.Code
+	}{
+		{&proto.ExecRequest{Command: "ls", User: "root"}, codes.PermissionDenied},
+		{&proto.ExecRequest{Command: "ls", WorkingDir: "tmp"}, codes.InvalidArgument},
+		{&proto.ExecRequest{Command: "ls", WorkingDir: "/tmp/../etc"}, codes.PermissionDenied},
+		{&proto.ExecRequest{Command: "ls", Env: []string{"=bar"}}, codes.InvalidArgument},
+	}
+	for _, test := range tests {
+		_, err := service.Exec(ctx, test.req)
+		if e, _ := status.FromError(err); e.Code() != test.code {
+			t.Errorf("%v: expected %v got: %v", test.req, test.code, e.Code())
+		}
+	}
+}
+
+func TestExecEnv(t *testing.T) {
+	os.Setenv("JOB_WORKER_SECRET", "secret")
+	defer os.Unsetenv("JOB_WORKER_SECRET")
+
+	jobStore := core.NewMemoryStore()
+	service := api.NewJobService(jobStore, api.Config{
+		BaseEnv: []string{"FOO=base", "BAR=base"},
+	})
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+
+	resp, err := service.Exec(ctx, &proto.ExecRequest{
+		Command:    "env",
+		Env:        []string{"FOO=bar"},
+		WorkingDir: "/tmp",
+	})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	job, _ := jobStore.Get(resp.GetId())
+	for !job.Status().Finished() {
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	r, _ := job.OutputBuf.NewReader()
+	defer r.Close()
+	b, _ := ioutil.ReadAll(r)
+	env := strings.Fields(string(b))
+	for _, want := range []string{"FOO=bar", "BAR=base"} {
+		if !contains(env, want) {
+			t.Errorf("expected %v in environment %v", want, env)
+		}
+	}
+	if strings.Contains(string(b), "JOB_WORKER_SECRET") {
+		t.Errorf("server environment leaked into job %v", env)
+	}
+}
+
+func TestExecOutputLimit(t *testing.T) {
+	jobStore := core.NewMemoryStore()
+	service := api.NewJobService(jobStore, api.Config{MaxOutputBytes: 5})
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+
+	_, err := service.Exec(ctx, &proto.ExecRequest{
+		Command:     "echo",
+		OutputLimit: &proto.OutputLimit{MaxBytes: 10},
+	})
+	if e, _ := status.FromError(err); e.Code() != codes.InvalidArgument {
+		t.Errorf("expected invalid argument got: %v", e.Code())
+	}
+	// Each of the default 4 segments must hold at least a byte
+	_, err = service.Exec(ctx, &proto.ExecRequest{
+		Command:     "echo",
+		OutputLimit: &proto.OutputLimit{MaxBytes: 3, Policy: proto.OutputPolicy_OUTPUT_POLICY_ROTATE},
+	})
+	if e, _ := status.FromError(err); e.Code() != codes.InvalidArgument {
+		t.Errorf("expected invalid argument for too many segments got: %v", e.Code())
+	}
+
+	resp, err := service.Exec(ctx, &proto.ExecRequest{Command: "echo", Args: []string{"hello", "world"}})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	job, _ := jobStore.Get(resp.GetId())
+	for !job.Status().Finished() {
+		time.Sleep(time.Millisecond * 10)
+	}
+	statusResp, err := service.Status(ctx, &proto.StatusRequest{Id: resp.GetId()})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	if !statusResp.GetOutputTruncated() || statusResp.GetOutputDroppedBytes() != 7 {
+		t.Errorf("expected 7 bytes of output dropped got: %v", statusResp)
+	}
+}
+
+// attachStream is an in memory attach stream
+type attachStream struct {
+	proto.JobService_AttachServer
+	ctx  context.Context
+	reqs chan *proto.AttachRequest
+	out  strings.Builder
+}
+
+func (s *attachStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *attachStream) Recv() (*proto.AttachRequest, error) {
+	req, ok := <-s.reqs
+	if !ok {
+		return nil, io.EOF
+	}
+	return req, nil
+}
+
+func (s *attachStream) Send(resp *proto.AttachResponse) error {
+	s.out.Write(resp.GetData())
+	return nil
+}
+
+func TestAttach(t *testing.T) {
+	service := mockService()
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+	resp, err := service.Exec(ctx, &proto.ExecRequest{
+		Command: "sh",
+		Args:    []string{"-c", "read line; echo got $line"},
+		Tty:     true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+
+	stream := &attachStream{ctx: ctx, reqs: make(chan *proto.AttachRequest, 2)}
+	stream.reqs <- &proto.AttachRequest{Id: resp.GetId(), Replay: true, Resize: &proto.TerminalSize{Rows: 24, Cols: 80}}
+	stream.reqs <- &proto.AttachRequest{Data: []byte("hi\r")}
+	close(stream.reqs)
+	if err = service.Attach(stream); err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	if out := stream.out.String(); !strings.Contains(out, "got hi") {
+		t.Errorf("expected reply in terminal output got: %q", out)
+	}
+
+	// Jobs without a tty can not be attached to
+	resp, _ = service.Exec(ctx, &proto.ExecRequest{Command: "true"})
+	stream = &attachStream{ctx: ctx, reqs: make(chan *proto.AttachRequest, 1)}
+	stream.reqs <- &proto.AttachRequest{Id: resp.GetId()}
+	err = service.Attach(stream)
+	if e, _ := status.FromError(err); e.Code() != codes.FailedPrecondition {
+		t.Errorf("expected failed precondition got: %v", e.Code())
+	}
+}
+
+// logsStream is an in memory logs stream
+type logsStream struct {
+	proto.JobService_LogsServer
+	ctx   context.Context
+	resps []*proto.LogResponse
+}
+
+func (s *logsStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *logsStream) Send(resp *proto.LogResponse) error {
+	s.resps = append(s.resps, resp)
+	return nil
+}
+
+func TestLogsFollow(t *testing.T) {
+	service := mockService()
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+	resp, err := service.Exec(ctx, &proto.ExecRequest{
+		Command: "sh",
+		Args:    []string{"-c", "echo out; sleep 0.2; echo err >&2"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+
+	// Logs follows the job until it is done by default
+	stream := &logsStream{ctx: ctx}
+	if err = service.Logs(&proto.LogRequest{Id: resp.GetId()}, stream); err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	var got []string
+	for _, r := range stream.resps {
+		got = append(got, r.GetStream()+":"+string(r.GetLog()))
+	}
+	if want := []string{"stdout:out\n", "stderr:err\n"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("logs want: %q got: %q", want, got)
+	}
+}
+
+func TestLogsOptions(t *testing.T) {
+	jobStore := core.NewMemoryStore()
+	service := api.NewJobService(jobStore, api.Config{})
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+	resp, err := service.Exec(ctx, &proto.ExecRequest{
+		Command: "sh",
+		Args:    []string{"-c", "echo one; echo two; sleep 0.1; echo three"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	job, _ := jobStore.Get(resp.GetId())
+	<-job.Done()
+
+	// The time the last line was written
+	r, _ := job.OutputBuf.NewReader()
+	r.SeekTail(1)
+	last, _ := r.Next()
+	r.Close()
+
+	tests := []struct {
+		req  *proto.LogRequest
+		want string
+	}{
+		{&proto.LogRequest{}, "one\ntwo\nthree\n"},
+		{&proto.LogRequest{NoFollow: true}, "one\ntwo\nthree\n"},
+		{&proto.LogRequest{Tail: 2}, "two\nthree\n"},
+		{&proto.LogRequest{Offset: 5}, "wo\nthree\n"},
+		{&proto.LogRequest{Offset: 10, Tail: 2}, "ree\n"},
+		{&proto.LogRequest{SinceUnixNano: last.Time.UnixNano()}, "three\n"},
+	}
+	for _, test := range tests {
+		test.req.Id = resp.GetId()
+		stream := &logsStream{ctx: ctx}
+		if err = service.Logs(test.req, stream); err != nil {
+			t.Fatalf("expected no error got: %v", err)
+		}
+		// Offsets count the bytes of both streams from the start of the output
+		var got []byte
+		for _, r := range stream.resps {
+			if want := int64(len("one\ntwo\nthree\n") - len(test.want) + len(got)); r.GetOffset() != want {
+				t.Errorf("%v: offset want: %v got: %v", test.req, want, r.GetOffset())
+			}
+			got = append(got, r.GetLog()...)
+		}
+		if string(got) != test.want {
+			t.Errorf("%v: logs want: %q got: %q", test.req, test.want, got)
+		}
+	}
+
+	err = service.Logs(&proto.LogRequest{Id: resp.GetId(), Tail: -1}, &logsStream{ctx: ctx})
+	if e, _ := status.FromError(err); e.Code() != codes.InvalidArgument {
+		t.Errorf("expected invalid argument got: %v", e.Code())
+	}
+}
+
+func TestDelete(t *testing.T) {
+	jobStore := core.NewMemoryStore()
+	service := api.NewJobService(jobStore, api.Config{OutputDir: t.TempDir()})
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+
+	resp, _ := service.Exec(ctx, &proto.ExecRequest{Command: "sleep", Args: []string{"5"}})
+	_, err := service.Delete(ctx, &proto.DeleteRequest{Id: resp.GetId()})
+	if e, _ := status.FromError(err); e.Code() != codes.FailedPrecondition {
+		t.Errorf("expected failed precondition got: %v", e.Code())
+	}
+	job, _ := jobStore.Get(resp.GetId())
+	for job.Status() != core.Running {
+		time.Sleep(time.Millisecond * 10)
+	}
+	job.Kill()
+	<-job.Done()
+
+	if _, err = service.Delete(ctx, &proto.DeleteRequest{Id: resp.GetId()}); err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	_, err = service.Status(ctx, &proto.StatusRequest{Id: resp.GetId()})
+	if e, _ := status.FromError(err); e.Code() != codes.NotFound {
+		t.Errorf("expected not found got: %v", e.Code())
+	}
+}
+
+// contains reports if s is in values
+func contains(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestList(t *testing.T) {
+	service := mockService()
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+	var ids []string
+	for i := 0; i < 5; i++ {
+		req := &proto.ExecRequest{Command: "echo", Args: []string{strconv.Itoa(i)}}
+		if i%2 == 0 {
+			req.Labels = map[string]string{"team": "ci"}
+		}
+		resp, err := service.Exec(ctx, req)
+		if err != nil {
+			t.Fatalf("expected no error got: %v", err)
+		}
+		ids = append(ids, resp.GetId())
+	}
+	other := context.WithValue(context.Background(), api.KeyClientID, "client2")
+	service.Exec(other, &proto.ExecRequest{Command: "echo"})
+
+	// Pages in creation order only hold the jobs of the client
+	var listed []string
+	req := &proto.ListRequest{PageSize: 2}
+	for {
+		resp, err := service.List(ctx, req)
+		if err != nil {
+			t.Fatalf("expected no error got: %v", err)
+		}
+		if len(resp.GetJobs()) > 2 {
+			t.Errorf("expected at most 2 jobs per page got: %v", len(resp.GetJobs()))
+		}
+		for _, job := range resp.GetJobs() {
+			listed = append(listed, job.GetId())
+		}
+		if req.PageToken = resp.GetNextPageToken(); req.PageToken == "" {
+			break
+		}
+	}
+	if strings.Join(listed, " ") != strings.Join(ids, " ") {
+		t.Errorf("listed jobs want: %v got: %v", ids, listed)
+	}
+
+	resp, _ := service.List(ctx, &proto.ListRequest{
+		Labels:     map[string]string{"team": "ci"},
+		Descending: true,
+	})
+	var want []string
+	for _, job := range resp.GetJobs() {
+		want = append(want, job.GetArgs()...)
+	}
+	if strings.Join(want, " ") != "4 2 0" {
+		t.Errorf("expected labelled jobs newest first got: %v", want)
+	}
+
+	_, err := service.List(ctx, &proto.ListRequest{PageToken: "bogus"})
+	if e, _ := status.FromError(err); e.Code() != codes.InvalidArgument {
+		t.Errorf("expected invalid argument got: %v", e.Code())
+	}
+}
+
+func TestSignalStop(t *testing.T) {
+	service := mockService()
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+	resp, err := service.Exec(ctx, &proto.ExecRequest{Command: "sleep", Args: []string{"5"}})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	defer service.Stop(ctx, &proto.StopRequest{Id: resp.GetId(), Signal: "SIGKILL"})
+	for {
+		s, _ := service.Status(ctx, &proto.StatusRequest{Id: resp.GetId()})
+		if s.GetJobStatus() == proto.JobStatus_JOB_STATUS_RUNNING {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	// Stopping the init process would leave the job running
+	for _, sig := range []string{"SIGSTOP", "SIGCONT"} {
+		_, err = service.Signal(ctx, &proto.SignalRequest{Id: resp.GetId(), Signal: sig})
+		if e, _ := status.FromError(err); e.Code() != codes.InvalidArgument {
+			t.Errorf("%v: expected invalid argument got: %v", sig, err)
+		}
+		_, err = service.Stop(ctx, &proto.StopRequest{Id: resp.GetId(), Signal: sig})
+		if e, _ := status.FromError(err); e.Code() != codes.InvalidArgument {
+			t.Errorf("%v: expected invalid argument got: %v", sig, err)
+		}
+	}
+}
+
+func TestWait(t *testing.T) {
+	service := mockService()
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+
+	resp, err := service.Exec(ctx, &proto.ExecRequest{Command: "sh", Args: []string{"-c", "sleep 0.2; exit 3"}})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+
+	short, cancel := context.WithTimeout(ctx, time.Millisecond*10)
+	defer cancel()
+	_, err = service.Wait(short, &proto.WaitRequest{Id: resp.GetId()})
+	if e, _ := status.FromError(err); e.Code() != codes.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded got: %v", e.Code())
+	}
+
+	waitResp, err := service.Wait(ctx, &proto.WaitRequest{Id: resp.GetId()})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	if waitResp.GetJobStatus() != proto.JobStatus_JOB_STATUS_FAILED || waitResp.GetExitCode() != 3 {
+		t.Errorf("expected failed status and exit code 3 got: %v", waitResp)
+	}
+	if waitResp.GetCommand() != "sh" || len(waitResp.GetArgs()) != 2 {
+		t.Errorf("expected the command and args got: %v %v", waitResp.GetCommand(), waitResp.GetArgs())
+	}
+	created, started, finished := waitResp.GetCreatedUnixNano(), waitResp.GetStartedUnixNano(), waitResp.GetFinishedUnixNano()
+	if created == 0 || started < created || finished < started {
+		t.Errorf("expected created, started and finished times in order got: %v %v %v", created, started, finished)
+	}
+	if waitResp.GetDurationMs() < 150 || waitResp.GetUsage() == nil || waitResp.GetSignal() != "" {
+		t.Errorf("expected duration and usage without a signal got: %v", waitResp)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	service := mockService()
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+
+	cases := []struct {
+		command string
+		args    []string
+		status  proto.JobStatus
+		code    proto.ErrorCode
+		message string
+		signal  string
+	}{
+		{"sh", []string{"-c", "exit 0"}, proto.JobStatus_JOB_STATUS_COMPLETE, 0, "", ""},
+		{"sh", []string{"-c", "exit 2"}, proto.JobStatus_JOB_STATUS_FAILED, proto.ErrorCode_ERROR_CODE_EXIT_STATUS, "job exited with code 2", ""},
+		{"sh", []string{"-c", "kill -TERM $$"}, proto.JobStatus_JOB_STATUS_KILLED, proto.ErrorCode_ERROR_CODE_SIGNALED, "job was killed by SIGTERM", "SIGTERM"},
+		{"job-worker-missing", nil, proto.JobStatus_JOB_STATUS_START_FAILED, proto.ErrorCode_ERROR_CODE_START_FAILED, "command not found: job-worker-missing", ""},
+	}
+	for _, tc := range cases {
+		resp, err := service.Exec(ctx, &proto.ExecRequest{Command: tc.command, Args: tc.args})
+		if err != nil {
+			t.Fatalf("expected no error got: %v", err)
+		}
+		status, err := service.Wait(ctx, &proto.WaitRequest{Id: resp.GetId()})
+		if err != nil {
+			t.Fatalf("expected no error got: %v", err)
+		}
+		if status.GetJobStatus() != tc.status || status.GetSignal() != tc.signal {
+			t.Errorf("%v %v: want: %v %q got: %v %q", tc.command, tc.args, tc.status, tc.signal, status.GetJobStatus(), status.GetSignal())
+		}
+		if jobErr := status.GetJobError(); jobErr.GetCode() != tc.code || jobErr.GetMessage() != tc.message {
+			t.Errorf("%v %v: error want: %v %q got: %v", tc.command, tc.args, tc.code, tc.message, jobErr)
+		}
+	}
+}
+
+// watchStream is an in memory watch stream
+type watchStream struct {
+	proto.JobService_WatchServer
+	ctx    context.Context
+	events []*proto.Event
+}
+
+func (s *watchStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *watchStream) Send(e *proto.Event) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func TestWatch(t *testing.T) {
+	service := api.NewJobService(core.NewMemoryStore(), api.Config{MaxRunning: 1})
+	ctx := context.WithValue(context.Background(), api.KeyClientID, "client1")
+	first, err := service.Exec(ctx, &proto.ExecRequest{Command: "sleep", Args: []string{"0.2"}})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	second, err := service.Exec(ctx, &proto.ExecRequest{Command: "sleep", Args: []string{"5"}})
+	if err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	other := context.WithValue(context.Background(), api.KeyClientID, "client2")
+	service.Exec(other, &proto.ExecRequest{Command: "true"})
+
+	// Watching a job replays its earlier events and ends once it has finished
+	stop := make(chan struct{})
+	go func() {
+		<-stop
+		for {
+			resp, _ := service.Status(ctx, &proto.StatusRequest{Id: second.GetId()})
+			if resp.GetJobStatus() == proto.JobStatus_JOB_STATUS_RUNNING {
+				break
+			}
+			time.Sleep(time.Millisecond * 10)
+		}
+		service.Stop(ctx, &proto.StopRequest{Id: second.GetId()})
+	}()
+	stream := &watchStream{ctx: ctx}
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		service.Watch(&proto.WatchRequest{Id: first.GetId()}, &watchStream{ctx: ctx})
+		close(stop)
+	}()
+	if err = service.Watch(&proto.WatchRequest{Id: second.GetId()}, stream); err != nil {
+		t.Fatalf("expected no error got: %v", err)
+	}
+	<-firstDone
+
+	want := []proto.EventType{
+		proto.EventType_EVENT_TYPE_CREATED,
+		proto.EventType_EVENT_TYPE_QUEUED,
+		proto.EventType_EVENT_TYPE_STARTED,
+		proto.EventType_EVENT_TYPE_STOPPED,
+	}
+	var got []proto.EventType
+	for i, e := range stream.events {
+		got = append(got, e.GetType())
+		if e.GetId() != second.GetId() {
+			t.Errorf("expected only events of %v got: %v", second.GetId(), e)
+		}
+		if i > 0 && e.GetSeq() <= stream.events[i-1].GetSeq() {
+			t.Errorf("expected increasing seq got: %v after %v", e.GetSeq(), stream.events[i-1].GetSeq())
+		}
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("events want: %v got: %v", want, got)
+	}
+
+	// Watching every job resumes after a seq and never sees other clients
+	all, cancel := context.WithTimeout(ctx, time.Millisecond*100)
+	defer cancel()
+	stream = &watchStream{ctx: all}
+	service.Watch(&proto.WatchRequest{AfterSeq: 2}, stream)
+	for _, e := range stream.events {
+		if e.GetSeq() <= 2 {
+			t.Errorf("expected events after seq 2 got: %v", e.GetSeq())
+		}
+		if e.GetId() != first.GetId() && e.GetId() != second.GetId() {
+			t.Errorf("expected only events of client1 got: %v", e)
+		}
+	}
+}
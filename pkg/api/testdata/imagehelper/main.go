@@ -0,0 +1,37 @@
+// imagehelper is built statically into a test image and reports what a job run in
+// the image can see and write
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+)
+
+func main() {
+	// Only the init process and the helper are visible in a new pid namespace
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		fmt.Println("proc:", err)
+		os.Exit(1)
+	}
+	procs := 0
+	for _, e := range entries {
+		if _, err := strconv.Atoi(e.Name()); err == nil {
+			procs++
+		}
+	}
+	fmt.Println("procs:", procs)
+
+	if err = ioutil.WriteFile("/base", []byte("base\n"), 0644); err != nil {
+		fmt.Println("base: read only")
+	} else {
+		fmt.Println("base: writable")
+	}
+	if err = ioutil.WriteFile("/workspace/out", []byte("workspace\n"), 0644); err != nil {
+		fmt.Println("workspace:", err)
+	} else {
+		fmt.Println("workspace: writable")
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"github.com/dboslee/job-worker/pkg/api/proto"
+	"github.com/dboslee/job-worker/pkg/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchBehind is returned when a watcher does not keep up with the events
+var watchBehind = status.Error(codes.ResourceExhausted, "watcher fell behind, resume with after_seq")
+
+// eventTypes maps core event types to proto event types
+var eventTypes = map[core.EventType]proto.EventType{
+	core.EventCreated:         proto.EventType_EVENT_TYPE_CREATED,
+	core.EventQueued:          proto.EventType_EVENT_TYPE_QUEUED,
+	core.EventStarted:         proto.EventType_EVENT_TYPE_STARTED,
+	core.EventExited:          proto.EventType_EVENT_TYPE_EXITED,
+	core.EventStopped:         proto.EventType_EVENT_TYPE_STOPPED,
+	core.EventOutputTruncated: proto.EventType_EVENT_TYPE_OUTPUT_TRUNCATED,
+}
+
+// Watch streams the lifecycle events of a job, or of every job of the client when no
+// id is given. Watching a single job ends once it has finished.
+func (js *JobService) Watch(req *proto.WatchRequest, serv proto.JobService_WatchServer) error {
+	ctx := serv.Context()
+	cID := ctx.Value(KeyClientID)
+	if cID == nil || cID.(string) == "" {
+		return PermissionDenied
+	}
+	var done <-chan struct{}
+	if req.GetId() != "" {
+		job, err := js.getJob(ctx, req.GetId())
+		if err != nil {
+			return err
+		}
+		done = job.Done()
+	}
+
+	events, cancel := js.events.Subscribe(req.GetAfterSeq(), func(e core.Event) bool {
+		return e.ClientID == cID.(string) && (req.GetId() == "" || e.JobID == req.GetId())
+	})
+	defer cancel()
+
+	send := func(e core.Event) error {
+		return serv.Send(&proto.Event{
+			Seq:          e.Seq,
+			Type:         eventTypes[e.Type],
+			Id:           e.JobID,
+			TimeUnixNano: e.Time.UnixNano(),
+			Status:       jobStatuses[e.Status],
+			ExitCode:     int64(e.ExitCode),
+		})
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-done:
+			// The final event is published before the job is done
+			for {
+				select {
+				case e, ok := <-events:
+					if !ok {
+						return watchBehind
+					}
+					if err := send(e); err != nil {
+						return err
+					}
+				default:
+					return nil
+				}
+			}
+		case e, ok := <-events:
+			if !ok {
+				return watchBehind
+			}
+			if err := send(e); err != nil {
+				return err
+			}
+		}
+	}
+}
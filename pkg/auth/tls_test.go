@@ -26,7 +26,7 @@ func NewClient(ctx context.Context, clientCreds credentials.TransportCredentials
 
 	listener = bufconn.Listen(1024 * 1024)
 
-	service := api.NewJobService(core.NewJobStore())
+	service := api.NewJobService(core.NewMemoryStore(), api.Config{})
 	s := grpc.NewServer(
 		grpc.Creds(serverCreds),
 		grpc.UnaryInterceptor(api.AuthUnary),
@@ -2,13 +2,32 @@ package cli
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"io"
 	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
 
 	"github.com/dboslee/job-worker/pkg/api/proto"
 )
 
+// ExitError is returned by commands which exit with the exit code of a job
+type ExitError struct {
+	Code int
+}
+
+// Error implements error
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("job exited with code %v", e.Code)
+}
+
 // Client provides a way to interact with the grpc server through the cli
 type Client struct {
 	ctx        context.Context
@@ -37,12 +56,32 @@ func (c *Client) HandleArgs(args []string) error {
 	switch subcommand {
 	case "exec":
 		return c.exec(args[2:])
+	case "run":
+		return c.run(args[2:])
 	case "status":
 		return c.status(args[2:])
 	case "stop":
 		return c.stop(args[2:])
+	case "signal":
+		return c.signal(args[2:])
+	case "pause":
+		return c.pause(args[2:])
+	case "resume":
+		return c.resume(args[2:])
 	case "logs":
 		return c.logs(args[2:])
+	case "stdin":
+		return c.stdin(args[2:])
+	case "attach":
+		return c.attach(args[2:])
+	case "delete":
+		return c.delete(args[2:])
+	case "list", "ps":
+		return c.list(args[2:])
+	case "wait":
+		return c.wait(args[2:])
+	case "watch":
+		return c.watch(args[2:])
 	default:
 		return fmt.Errorf("unknown subcommand %v", subcommand)
 	}
@@ -51,21 +90,259 @@ func (c *Client) HandleArgs(args []string) error {
 
 // exec calls the exec rpc and outputs the job id
 func (c *Client) exec(args []string) error {
-	if len(args) == 0 {
-		return fmt.Errorf("must provide a command to execute")
-	}
-	req := &proto.ExecRequest{
-		Command: args[0],
-		Args:    args[1:],
+	req, err := parseExec("exec", args)
+	if err != nil {
+		return err
 	}
 	resp, err := c.jobService.Exec(c.ctx, req)
 	if err != nil {
 		return err
 	}
 	log.Print(resp.GetId())
+	if req.GetTty() {
+		return c.attachTerminal(resp.GetId(), true)
+	}
+	if req.GetStdin() {
+		return c.writeStdin(resp.GetId(), os.Stdin)
+	}
 	return nil
 }
 
+// run executes a command, streams its output until it finishes and returns an
+// ExitError with its exit code. Interrupting the client stops the job.
+func (c *Client) run(args []string) error {
+	req, err := parseExec("run", args)
+	if err != nil {
+		return err
+	}
+	resp, err := c.jobService.Exec(c.ctx, req)
+	if err != nil {
+		return err
+	}
+	id := resp.GetId()
+
+	if req.GetTty() {
+		// Ctrl-C reaches the job through the terminal
+		if err = c.attachTerminal(id, true); err != nil {
+			return err
+		}
+	} else {
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(interrupt)
+		go func() {
+			for range interrupt {
+				_, err := c.jobService.Stop(c.ctx, &proto.StopRequest{Id: id})
+				if err != nil {
+					log.Printf("unable to stop job %v", err)
+				}
+			}
+		}()
+		if req.GetStdin() {
+			go func() {
+				if err := c.writeStdin(id, os.Stdin); err != nil {
+					log.Printf("unable to write stdin %v", err)
+				}
+			}()
+		}
+		if err = c.streamLogs(&proto.LogRequest{Id: id}); err != nil {
+			return err
+		}
+	}
+
+	status, err := c.jobService.Wait(c.ctx, &proto.WaitRequest{Id: id})
+	if err != nil {
+		return err
+	}
+	if status.GetJobError() != nil {
+		log.Printf("Error: %v", status.GetJobError().GetMessage())
+	}
+	return exitError(status)
+}
+
+// parseExec parses the flags of exec and run into an ExecRequest
+func parseExec(name string, args []string) (*proto.ExecRequest, error) {
+	limits := &proto.ResourceLimits{}
+	var io ioLimitsFlag
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Uint64Var(&limits.CpuWeight, "cpu-weight", 0, "relative cpu weight between 1 and 10000")
+	flags.Uint64Var(&limits.CpuMaxQuotaUs, "cpu-quota", 0, "cpu time in microseconds allowed each period")
+	flags.Uint64Var(&limits.CpuMaxPeriodUs, "cpu-period", 0, "cpu period in microseconds")
+	flags.Int64Var(&limits.MemoryMax, "memory-max", 0, "memory limit in bytes")
+	flags.Int64Var(&limits.MemoryHigh, "memory-high", 0, "memory throttling threshold in bytes")
+	flags.Var(&io, "io-max", "device io limit major:minor,rbps=N,wbps=N,riops=N,wiops=N (repeatable)")
+	isolation := flags.String("isolation", "", "isolation mode none or namespaces, defaults to the server setting")
+	image := flags.String("image", "", "name of a rootfs image registered on the server")
+	priority := flags.Int("priority", 0, "queue priority relative to your other jobs, higher runs first")
+	timeout := flags.Duration("timeout", 0, "stop the job once it has run this long, defaults to the server setting")
+	var env stringsFlag
+	flags.Var(&env, "env", "KEY=VALUE environment variable (repeatable)")
+	cleanEnv := flags.Bool("clean-env", false, "start from an empty environment instead of the server base environment")
+	dir := flags.String("dir", "", "absolute working directory")
+	user := flags.String("user", "", "username or uid[:gid] to run as")
+	stdin := flags.Bool("stdin", false, "pipe local stdin into the job")
+	tty := flags.Bool("tty", false, "run the job under a pseudo-terminal and attach to it")
+	flags.BoolVar(tty, "it", false, "shorthand for -tty")
+	outputLimit := &proto.OutputLimit{}
+	flags.Int64Var(&outputLimit.MaxBytes, "output-max", 0, "most output bytes kept, defaults to the server setting")
+	outputPolicy := flags.String("output-policy", "", "truncate, rotate or kill once output-max is reached")
+	segments := flags.Int("output-segments", 0, "number of segments output-max is split into by the rotate policy")
+	labels := labelsFlag{}
+	flags.Var(labels, "label", "KEY=VALUE label to find the job with (repeatable)")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	limits.Io = io
+	args = flags.Args()
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf("must provide a command to execute")
+	}
+	req := &proto.ExecRequest{
+		Command:     args[0],
+		Args:        args[1:],
+		Limits:      limits,
+		Image:       *image,
+		Priority:    int32(*priority),
+		TimeoutMs:   timeout.Milliseconds(),
+		Env:         env,
+		CleanEnv:    *cleanEnv,
+		WorkingDir:  *dir,
+		User:        *user,
+		Stdin:       *stdin,
+		Tty:         *tty,
+		OutputLimit: outputLimit,
+		Labels:      labels,
+	}
+	outputLimit.Segments = int32(*segments)
+	switch *outputPolicy {
+	case "":
+	case "truncate":
+		outputLimit.Policy = proto.OutputPolicy_OUTPUT_POLICY_TRUNCATE
+	case "rotate":
+		outputLimit.Policy = proto.OutputPolicy_OUTPUT_POLICY_ROTATE
+	case "kill":
+		outputLimit.Policy = proto.OutputPolicy_OUTPUT_POLICY_KILL
+	default:
+		return nil, fmt.Errorf("unknown output policy %v", *outputPolicy)
+	}
+	switch *isolation {
+	case "":
+	case "none":
+		req.Isolation = proto.Isolation_ISOLATION_NONE
+	case "namespaces":
+		req.Isolation = proto.Isolation_ISOLATION_NAMESPACES
+	default:
+		return nil, fmt.Errorf("unknown isolation %v", *isolation)
+	}
+	return req, nil
+}
+
+// stdin pipes local stdin into a job started with exec --stdin
+func (c *Client) stdin(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("must provide a job ID")
+	}
+	return c.writeStdin(args[0], os.Stdin)
+}
+
+// writeStdin streams r into the stdin of a job and closes it at EOF
+func (c *Client) writeStdin(id string, r io.Reader) error {
+	stream, err := c.jobService.WriteStdin(c.ctx)
+	if err != nil {
+		return err
+	}
+
+	b := make([]byte, 1024*32)
+	for {
+		n, err := r.Read(b)
+		req := &proto.StdinRequest{Id: id, Data: b[:n], Close: err == io.EOF}
+		if n > 0 || req.Close {
+			if serr := stream.Send(req); serr != nil {
+				// The real error is returned by CloseAndRecv
+				break
+			}
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
+// attach connects the local terminal to a job started with exec -tty
+func (c *Client) attach(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("must provide a job ID")
+	}
+	return c.attachTerminal(args[0], false)
+}
+
+// attachTerminal relays the local terminal in raw mode to the job until it is done
+func (c *Client) attachTerminal(id string, replay bool) error {
+	stream, err := c.jobService.Attach(c.ctx)
+	if err != nil {
+		return err
+	}
+	// Keystrokes and resizes are sent from separate goroutines
+	var mu sync.Mutex
+	send := func(req *proto.AttachRequest) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return stream.Send(req)
+	}
+
+	fd := int(os.Stdin.Fd())
+	err = send(&proto.AttachRequest{Id: id, Resize: terminalSize(fd), Replay: replay})
+	if err != nil {
+		return err
+	}
+	// Input is passed through untouched when stdin is not a terminal
+	if restore, err := makeRaw(fd); err == nil {
+		defer restore()
+	}
+
+	winch := make(chan os.Signal, 1)
+	signal.Notify(winch, syscall.SIGWINCH)
+	defer signal.Stop(winch)
+	go func() {
+		for range winch {
+			if size := terminalSize(fd); size != nil {
+				send(&proto.AttachRequest{Resize: size})
+			}
+		}
+	}()
+
+	go func() {
+		b := make([]byte, 1024)
+		for {
+			n, err := os.Stdin.Read(b)
+			if n > 0 && send(&proto.AttachRequest{Data: b[:n]}) != nil {
+				return
+			}
+			if err != nil {
+				mu.Lock()
+				stream.CloseSend()
+				mu.Unlock()
+				return
+			}
+		}
+	}()
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		os.Stdout.Write(resp.GetData())
+	}
+}
+
 // status calls the status rpc and outputs the status
 func (c *Client) status(args []string) error {
 	if len(args) == 0 {
@@ -78,19 +355,136 @@ func (c *Client) status(args []string) error {
 	if err != nil {
 		return err
 	}
-	log.Printf("Status: %v", resp.GetStatus())
+	log.Printf("Status: %v", statusName(resp.GetJobStatus()))
+	log.Printf("Command: %v", strings.Join(append([]string{resp.GetCommand()}, resp.GetArgs()...), " "))
+	log.Printf("Created: %v", formatUnixNano(resp.GetCreatedUnixNano()))
+	if resp.GetStartedUnixNano() != 0 {
+		log.Printf("Started: %v", formatUnixNano(resp.GetStartedUnixNano()))
+		log.Printf("Duration: %v", time.Duration(resp.GetDurationMs())*time.Millisecond)
+	}
+	if resp.GetFinishedUnixNano() != 0 {
+		log.Printf("Finished: %v", formatUnixNano(resp.GetFinishedUnixNano()))
+	}
+	if resp.GetQueuePosition() > 0 {
+		log.Printf("QueuePosition: %v", resp.GetQueuePosition())
+	}
+	if resp.GetImage() != "" {
+		log.Printf("Image: %v", resp.GetImage())
+	}
+	if resp.GetOutputTruncated() {
+		log.Printf("OutputDroppedBytes: %v", resp.GetOutputDroppedBytes())
+	}
 	log.Printf("ExitCode: %v", resp.GetExitCode())
-	log.Printf("Error: %v", resp.GetError())
+	if resp.GetSignal() != "" {
+		log.Printf("Signal: %v", resp.GetSignal())
+	}
+	if jobErr := resp.GetJobError(); jobErr != nil {
+		log.Printf("Error: %v (%v)", jobErr.GetMessage(), errorCodeName(jobErr.GetCode()))
+	}
+	if usage := resp.GetUsage(); usage != nil {
+		log.Printf("UserCPU: %v", time.Duration(usage.GetUserCpuUs())*time.Microsecond)
+		log.Printf("SystemCPU: %v", time.Duration(usage.GetSystemCpuUs())*time.Microsecond)
+		log.Printf("MaxRSS: %v bytes", usage.GetMaxRssBytes())
+	}
+	if cg := resp.GetCgroup(); cg != nil {
+		log.Printf("CgroupCPU: %v", time.Duration(cg.GetCpuUsageUs())*time.Microsecond)
+		log.Printf("CgroupMemory: %v bytes current %v bytes peak", cg.GetMemoryCurrentBytes(), cg.GetMemoryPeakBytes())
+		log.Printf("CgroupIO: %v bytes read %v bytes written", cg.GetIoReadBytes(), cg.GetIoWriteBytes())
+	}
 	return nil
 }
 
+// statusName returns the lower case name of a job status such as "timed_out"
+func statusName(s proto.JobStatus) string {
+	return strings.ToLower(strings.TrimPrefix(s.String(), "JOB_STATUS_"))
+}
+
+// errorCodeName returns the lower case name of an error code such as "start_failed"
+func errorCodeName(c proto.ErrorCode) string {
+	return strings.ToLower(strings.TrimPrefix(c.String(), "ERROR_CODE_"))
+}
+
+// parseStatus converts a name returned by statusName back to a job status
+func parseStatus(name string) (proto.JobStatus, error) {
+	s, ok := proto.JobStatus_value["JOB_STATUS_"+strings.ToUpper(name)]
+	if !ok || s == int32(proto.JobStatus_JOB_STATUS_UNKNOWN) {
+		return 0, fmt.Errorf("unknown status %v", name)
+	}
+	return proto.JobStatus(s), nil
+}
+
+// formatUnixNano formats a timestamp from the api in local time
+func formatUnixNano(ns int64) string {
+	return time.Unix(0, ns).Format(time.RFC3339)
+}
+
+// wait calls the wait rpc and returns an ExitError unless the job exited with code 0
+func (c *Client) wait(args []string) error {
+	flags := flag.NewFlagSet("wait", flag.ContinueOnError)
+	timeout := flags.Duration("timeout", 0, "give up waiting after this long, zero waits forever")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+
+	if len(args) == 0 {
+		return fmt.Errorf("must provide a job ID")
+	}
+	ctx := c.ctx
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	resp, err := c.jobService.Wait(ctx, &proto.WaitRequest{Id: args[0]})
+	if err != nil {
+		return err
+	}
+	if resp.GetJobError() != nil {
+		log.Printf("Error: %v", resp.GetJobError().GetMessage())
+	}
+	return exitError(resp)
+}
+
+// exitError returns an ExitError with the exit code of a finished job or nil if it succeeded.
+// Like a shell, jobs killed by signal n exit with 128+n and jobs that failed to start with
+// 127, other jobs without an exit code exit with 1.
+func exitError(resp *proto.StatusResponse) error {
+	code := int(resp.GetExitCode())
+	switch {
+	case resp.GetSignalNumber() != 0:
+		code = 128 + int(resp.GetSignalNumber())
+	case resp.GetJobStatus() == proto.JobStatus_JOB_STATUS_START_FAILED:
+		code = 127
+	case code < 0:
+		code = 1
+	}
+	if code == 0 && resp.GetJobError() == nil {
+		return nil
+	}
+	if code == 0 {
+		code = 1
+	}
+	return &ExitError{Code: code}
+}
+
 // stop calls the stop rpc
 func (c *Client) stop(args []string) error {
+	flags := flag.NewFlagSet("stop", flag.ContinueOnError)
+	sig := flags.String("signal", "", "first signal sent, defaults to SIGINT then escalates through SIGTERM to SIGKILL")
+	grace := flags.Duration("grace", 0, "time between escalating signals, defaults to the server setting")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+
 	if len(args) == 0 {
 		return fmt.Errorf("must provide a job ID")
 	}
 	req := &proto.StopRequest{
-		Id: args[0],
+		Id:            args[0],
+		Signal:        *sig,
+		GracePeriodMs: grace.Milliseconds(),
 	}
 
 	// There should be an error if anything goes wrong so we don't really need to check the response
@@ -101,27 +495,205 @@ func (c *Client) stop(args []string) error {
 	return nil
 }
 
+// signal calls the signal rpc
+func (c *Client) signal(args []string) error {
+	if len(args) < 2 {
+		return fmt.Errorf("must provide a job ID and signal")
+	}
+	req := &proto.SignalRequest{
+		Id:     args[0],
+		Signal: args[1],
+	}
+	_, err := c.jobService.Signal(c.ctx, req)
+	return err
+}
+
+// pause calls the pause rpc
+func (c *Client) pause(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("must provide a job ID")
+	}
+	_, err := c.jobService.Pause(c.ctx, &proto.PauseRequest{Id: args[0]})
+	return err
+}
+
+// resume calls the resume rpc
+func (c *Client) resume(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("must provide a job ID")
+	}
+	_, err := c.jobService.Resume(c.ctx, &proto.ResumeRequest{Id: args[0]})
+	return err
+}
+
+// delete calls the delete rpc
+func (c *Client) delete(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("must provide a job ID")
+	}
+	_, err := c.jobService.Delete(c.ctx, &proto.DeleteRequest{Id: args[0]})
+	return err
+}
+
+// list calls the list rpc and outputs a table of jobs
+func (c *Client) list(args []string) error {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	var statuses stringsFlag
+	flags.Var(&statuses, "status", "only list jobs with this status (repeatable)")
+	command := flags.String("command", "", "only list jobs whose command contains this")
+	labels := labelsFlag{}
+	flags.Var(labels, "label", "only list jobs with this KEY=VALUE label (repeatable)")
+	since := flags.String("since", "", "only list jobs created after a duration ago such as 10m or an RFC3339 time")
+	before := flags.String("before", "", "only list jobs created before a duration ago or an RFC3339 time")
+	sortBy := flags.String("sort", "created", "sort by created, finished, command or status")
+	reverse := flags.Bool("reverse", false, "sort in descending order")
+	limit := flags.Int("limit", 0, "most jobs listed, zero lists every job")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	req := &proto.ListRequest{
+		Command:    *command,
+		Labels:     labels,
+		Descending: *reverse,
+	}
+	if *since != "" {
+		t, err := parseSince(*since)
+		if err != nil {
+			return err
+		}
+		req.CreatedAfterUnixNano = t.UnixNano()
+	}
+	if *before != "" {
+		t, err := parseSince(*before)
+		if err != nil {
+			return err
+		}
+		req.CreatedBeforeUnixNano = t.UnixNano()
+	}
+	for _, name := range statuses {
+		s, err := parseStatus(name)
+		if err != nil {
+			return err
+		}
+		req.Status = append(req.Status, s)
+	}
+	sort, ok := proto.ListSort_value["LIST_SORT_"+strings.ToUpper(*sortBy)]
+	if !ok {
+		return fmt.Errorf("unknown sort %v", *sortBy)
+	}
+	req.Sort = proto.ListSort(sort)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tEXIT\tCREATED\tCOMMAND")
+	listed := 0
+	for {
+		if *limit > 0 {
+			req.PageSize = int32(*limit - listed)
+		}
+		resp, err := c.jobService.List(c.ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, job := range resp.GetJobs() {
+			exit := ""
+			if job.GetFinishedUnixNano() != 0 {
+				exit = strconv.FormatInt(job.GetExitCode(), 10)
+			}
+			created := time.Unix(0, job.GetCreatedUnixNano()).Format("2006-01-02 15:04:05")
+			cmd := strings.Join(append([]string{job.GetCommand()}, job.GetArgs()...), " ")
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", job.GetId(), statusName(job.GetStatus()), exit, created, cmd)
+		}
+		listed += len(resp.GetJobs())
+		req.PageToken = resp.GetNextPageToken()
+		if req.PageToken == "" || (*limit > 0 && listed >= *limit) {
+			break
+		}
+	}
+	return w.Flush()
+}
+
+// watch calls the watch rpc and outputs a line for each event
+func (c *Client) watch(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	after := flags.Uint64("after", 0, "replay recent events after this seq")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+
+	req := &proto.WatchRequest{AfterSeq: *after}
+	if len(args) > 0 {
+		req.Id = args[0]
+	}
+	stream, err := c.jobService.Watch(c.ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		e, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		event := strings.ToLower(strings.TrimPrefix(e.GetType().String(), "EVENT_TYPE_"))
+		t := time.Unix(0, e.GetTimeUnixNano()).Format(time.RFC3339Nano)
+		fmt.Printf("%v %v %v %v %v exit_code=%v\n", e.GetSeq(), t, e.GetId(), event, statusName(e.GetStatus()), e.GetExitCode())
+	}
+}
+
 // logs calls the logs rpc to stream the output of a job
 func (c *Client) logs(args []string) error {
+	flags := flag.NewFlagSet("logs", flag.ContinueOnError)
+	offset := flags.Int64("offset", 0, "output offset to start from")
+	tail := flags.Int64("tail", 0, "only show the last N lines")
+	follow := flags.Bool("follow", true, "keep streaming new output until the job is done")
+	since := flags.String("since", "", "only show output written after a duration ago such as 10m or an RFC3339 time")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+
 	if len(args) == 0 {
 		return fmt.Errorf("must provide a job ID")
 	}
 	req := &proto.LogRequest{
-		Id: args[0],
+		Id:       args[0],
+		Offset:   *offset,
+		Tail:     *tail,
+		NoFollow: !*follow,
+	}
+	if *since != "" {
+		t, err := parseSince(*since)
+		if err != nil {
+			return err
+		}
+		req.SinceUnixNano = t.UnixNano()
 	}
+	return c.streamLogs(req)
+}
+
+// streamLogs writes the output of a job to the matching local stream
+func (c *Client) streamLogs(req *proto.LogRequest) error {
 	stream, err := c.jobService.Logs(c.ctx, req)
 	if err != nil {
 		return err
 	}
 
+	next := req.GetOffset()
 	for {
 		resp, err := stream.Recv()
 		if err == io.EOF {
 			return nil
 		} else if err != nil {
-			return err
+			return fmt.Errorf("%v, resume with --offset %v", err, next)
+		}
+		out := os.Stdout
+		if resp.GetStream() == "stderr" {
+			out = os.Stderr
 		}
-		log.Print(string(resp.GetLog()))
+		out.Write(resp.GetLog())
+		next = resp.GetOffset() + int64(len(resp.GetLog()))
 	}
-
 }
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dboslee/job-worker/pkg/api/proto"
+)
+
+// parseSince parses a duration before now or an RFC3339 time
+func parseSince(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid since %q", s)
+	}
+	return t, nil
+}
+
+// stringsFlag collects a repeated string flag
+type stringsFlag []string
+
+// String implements flag.Value
+func (f *stringsFlag) String() string {
+	return strings.Join(*f, " ")
+}
+
+// Set implements flag.Value
+func (f *stringsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// labelsFlag collects repeated KEY=VALUE flags
+type labelsFlag map[string]string
+
+// String implements flag.Value
+func (f labelsFlag) String() string {
+	var labels []string
+	for k, v := range f {
+		labels = append(labels, k+"="+v)
+	}
+	return strings.Join(labels, " ")
+}
+
+// Set implements flag.Value
+func (f labelsFlag) Set(value string) error {
+	kv := strings.SplitN(value, "=", 2)
+	if len(kv) != 2 || kv[0] == "" {
+		return fmt.Errorf("invalid label %q", value)
+	}
+	f[kv[0]] = kv[1]
+	return nil
+}
+
+// ioLimitsFlag parses repeated --io-max flags of the form major:minor,rbps=N,wbps=N,riops=N,wiops=N
+type ioLimitsFlag []*proto.IOLimit
+
+// String implements flag.Value
+func (f *ioLimitsFlag) String() string {
+	var limits []string
+	for _, l := range *f {
+		limits = append(limits, l.GetDevice())
+	}
+	return strings.Join(limits, " ")
+}
+
+// Set implements flag.Value
+func (f *ioLimitsFlag) Set(value string) error {
+	parts := strings.Split(value, ",")
+	limit := &proto.IOLimit{Device: parts[0]}
+	for _, part := range parts[1:] {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("invalid io limit %q", part)
+		}
+		n, err := strconv.ParseUint(kv[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid io limit %q", part)
+		}
+		switch kv[0] {
+		case "rbps":
+			limit.ReadBps = n
+		case "wbps":
+			limit.WriteBps = n
+		case "riops":
+			limit.ReadIops = n
+		case "wiops":
+			limit.WriteIops = n
+		default:
+			return fmt.Errorf("unknown io limit %q", kv[0])
+		}
+	}
+	*f = append(*f, limit)
+	return nil
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"syscall"
+	"unsafe"
+
+	"github.com/dboslee/job-worker/pkg/api/proto"
+)
+
+// makeRaw puts the terminal fd in raw mode and returns a function restoring its previous state
+func makeRaw(fd int) (func(), error) {
+	var old syscall.Termios
+	if err := ioctl(fd, syscall.TCGETS, unsafe.Pointer(&old)); err != nil {
+		return nil, err
+	}
+
+	// Equivalent to cfmakeraw(3)
+	raw := old
+	raw.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP |
+		syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	raw.Oflag &^= syscall.OPOST
+	raw.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cflag &^= syscall.CSIZE | syscall.PARENB
+	raw.Cflag |= syscall.CS8
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := ioctl(fd, syscall.TCSETS, unsafe.Pointer(&raw)); err != nil {
+		return nil, err
+	}
+	return func() {
+		ioctl(fd, syscall.TCSETS, unsafe.Pointer(&old))
+	}, nil
+}
+
+// terminalSize returns the size of the terminal fd or nil if it is not a terminal
+func terminalSize(fd int) *proto.TerminalSize {
+	var ws struct {
+		rows uint16
+		cols uint16
+		_    [2]uint16
+	}
+	if err := ioctl(fd, syscall.TIOCGWINSZ, unsafe.Pointer(&ws)); err != nil {
+		return nil
+	}
+	return &proto.TerminalSize{Rows: uint32(ws.rows), Cols: uint32(ws.cols)}
+}
+
+// ioctl performs an ioctl returning errno as an error
+func ioctl(fd int, req uintptr, arg unsafe.Pointer) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), req, uintptr(arg)); errno != 0 {
+		return errno
+	}
+	return nil
+}
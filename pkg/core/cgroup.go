@@ -0,0 +1,328 @@
+package core
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// DefaultCgroupParent is the cgroup v2 directory job cgroups are created under
+const DefaultCgroupParent = "/sys/fs/cgroup/job-worker"
+
+// cgroup2SuperMagic is the filesystem type of a cgroup v2 mount
+const cgroup2SuperMagic = 0x63677270
+
+// cgroupControllers are enabled on the parent so they are available to job cgroups
+var cgroupControllers = []string{"cpu", "memory", "io"}
+
+// IOLimit limits the bandwidth of a single block device
+type IOLimit struct {
+	// Device is the "major:minor" number of the block device
+	Device    string `json:"device"`
+	ReadBPS   uint64 `json:"read_bps"`
+	WriteBPS  uint64 `json:"write_bps"`
+	ReadIOPS  uint64 `json:"read_iops"`
+	WriteIOPS uint64 `json:"write_iops"`
+}
+
+// ResourceLimits are the cgroup v2 limits applied to a job, zero values are left unset
+type ResourceLimits struct {
+	CPUWeight      uint64    `json:"cpu_weight"`
+	CPUMaxQuotaUS  uint64    `json:"cpu_max_quota_us"`
+	CPUMaxPeriodUS uint64    `json:"cpu_max_period_us"`
+	MemoryMax      int64     `json:"memory_max"`
+	MemoryHigh     int64     `json:"memory_high"`
+	IO             []IOLimit `json:"io"`
+}
+
+// Validate checks the limits are within the ranges accepted by the kernel
+func (l *ResourceLimits) Validate() error {
+	if l.CPUWeight != 0 && (l.CPUWeight < 1 || l.CPUWeight > 10000) {
+		return fmt.Errorf("cpu weight must be between 1 and 10000")
+	}
+	if l.CPUMaxPeriodUS != 0 && l.CPUMaxQuotaUS == 0 {
+		return fmt.Errorf("cpu max period requires a quota")
+	}
+	if l.MemoryMax < 0 || l.MemoryHigh < 0 {
+		return fmt.Errorf("memory limits must not be negative")
+	}
+	for _, io := range l.IO {
+		parts := strings.Split(io.Device, ":")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid io device %q", io.Device)
+		}
+		for _, p := range parts {
+			if _, err := strconv.ParseUint(p, 10, 32); err != nil {
+				return fmt.Errorf("invalid io device %q", io.Device)
+			}
+		}
+	}
+	return nil
+}
+
+// files returns the cgroup interface files and values for the limits
+func (l *ResourceLimits) files() map[string]string {
+	files := make(map[string]string)
+	if l.CPUWeight != 0 {
+		files["cpu.weight"] = strconv.FormatUint(l.CPUWeight, 10)
+	}
+	if l.CPUMaxQuotaUS != 0 {
+		period := l.CPUMaxPeriodUS
+		if period == 0 {
+			period = 100000
+		}
+		files["cpu.max"] = fmt.Sprintf("%d %d", l.CPUMaxQuotaUS, period)
+	}
+	if l.MemoryMax != 0 {
+		files["memory.max"] = strconv.FormatInt(l.MemoryMax, 10)
+	}
+	if l.MemoryHigh != 0 {
+		files["memory.high"] = strconv.FormatInt(l.MemoryHigh, 10)
+	}
+
+	var io []string
+	for _, limit := range l.IO {
+		line := limit.Device
+		for _, v := range []struct {
+			key   string
+			value uint64
+		}{
+			{"rbps", limit.ReadBPS},
+			{"wbps", limit.WriteBPS},
+			{"riops", limit.ReadIOPS},
+			{"wiops", limit.WriteIOPS},
+		} {
+			if v.value != 0 {
+				line += fmt.Sprintf(" %s=%d", v.key, v.value)
+			}
+		}
+		io = append(io, line)
+	}
+	if len(io) > 0 {
+		files["io.max"] = strings.Join(io, "\n")
+	}
+	return files
+}
+
+// Cgroup is a cgroup v2 directory owned by a single job
+type Cgroup struct {
+	Path string
+}
+
+// NewCgroup creates a cgroup named name under parent and applies the limits
+func NewCgroup(parent string, name string, limits *ResourceLimits) (*Cgroup, error) {
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return nil, fmt.Errorf("unable to create cgroup parent %v", err)
+	}
+	for _, c := range cgroupControllers {
+		err := writeCgroupFile(filepath.Join(parent, "cgroup.subtree_control"), "+"+c)
+		if err != nil {
+			return nil, fmt.Errorf("unable to enable %v controller %v", c, err)
+		}
+	}
+
+	cg := &Cgroup{Path: filepath.Join(parent, name)}
+	if err := os.Mkdir(cg.Path, 0755); err != nil {
+		return nil, fmt.Errorf("unable to create cgroup %v", err)
+	}
+	if limits == nil {
+		return cg, nil
+	}
+	for file, value := range limits.files() {
+		// io.max only accepts a single device per write
+		for _, line := range strings.Split(value, "\n") {
+			if err := writeCgroupFile(filepath.Join(cg.Path, file), line); err != nil {
+				cg.Remove()
+				return nil, fmt.Errorf("unable to set %v %v", file, err)
+			}
+		}
+	}
+	return cg, nil
+}
+
+// CheckCgroupParent checks job cgroups can be created under parent by creating and
+// removing one, so a server without permission fails at startup rather than on every job
+func CheckCgroupParent(parent string) error {
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return fmt.Errorf("unable to create cgroup parent %v", err)
+	}
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(parent, &fs); err != nil {
+		return err
+	}
+	if fs.Type != cgroup2SuperMagic {
+		return fmt.Errorf("cgroup parent %v is not on a cgroup v2 filesystem", parent)
+	}
+	cg, err := NewCgroup(parent, fmt.Sprintf("check-%d", os.Getpid()), nil)
+	if err != nil {
+		return err
+	}
+	return cg.Remove()
+}
+
+// AddProcess moves a process into the cgroup
+func (c *Cgroup) AddProcess(pid int) error {
+	return writeCgroupFile(filepath.Join(c.Path, "cgroup.procs"), strconv.Itoa(pid))
+}
+
+// Kill sends SIGKILL to every process in the cgroup including any that left the job process group
+func (c *Cgroup) Kill() error {
+	// cgroup.kill is only available from linux 5.14
+	if err := writeCgroupFile(filepath.Join(c.Path, "cgroup.kill"), "1"); err == nil {
+		return nil
+	}
+	pids, err := c.Processes()
+	if err != nil {
+		return err
+	}
+	for _, pid := range pids {
+		syscall.Kill(pid, syscall.SIGKILL)
+	}
+	return nil
+}
+
+// Freeze freezes or thaws every process in the cgroup and waits for it to take effect
+func (c *Cgroup) Freeze(frozen bool) error {
+	value := "0"
+	if frozen {
+		value = "1"
+	}
+	if err := writeCgroupFile(filepath.Join(c.Path, "cgroup.freeze"), value); err != nil {
+		return err
+	}
+
+	want := "frozen " + value
+	for i := 0; i < 100; i++ {
+		b, err := ioutil.ReadFile(filepath.Join(c.Path, "cgroup.events"))
+		if err != nil {
+			return err
+		}
+		for _, line := range strings.Split(string(b), "\n") {
+			if line == want {
+				return nil
+			}
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	return fmt.Errorf("timed out waiting for cgroup %v", want)
+}
+
+// Processes returns the pids of the processes in the cgroup
+func (c *Cgroup) Processes() ([]int, error) {
+	b, err := ioutil.ReadFile(filepath.Join(c.Path, "cgroup.procs"))
+	if err != nil {
+		return nil, err
+	}
+	var pids []int
+	for _, field := range strings.Fields(string(b)) {
+		pid, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, err
+		}
+		pids = append(pids, pid)
+	}
+	return pids, nil
+}
+
+// CgroupStats are the accounting counters of a cgroup
+type CgroupStats struct {
+	CPUUsage  time.Duration `json:"cpu_usage"`
+	CPUUser   time.Duration `json:"cpu_user"`
+	CPUSystem time.Duration `json:"cpu_system"`
+	// MemoryCurrent and MemoryPeak are in bytes, memory.peak is only available from linux 5.19
+	MemoryCurrent int64  `json:"memory_current"`
+	MemoryPeak    int64  `json:"memory_peak"`
+	IOReadBytes   uint64 `json:"io_read_bytes"`
+	IOWriteBytes  uint64 `json:"io_write_bytes"`
+}
+
+// Stats reads the accounting counters of the cgroup, counters of controllers
+// that are not enabled are left zero
+func (c *Cgroup) Stats() (CgroupStats, error) {
+	var stats CgroupStats
+	cpu, err := readCgroupKeys(filepath.Join(c.Path, "cpu.stat"))
+	if err != nil {
+		return stats, err
+	}
+	stats.CPUUsage = time.Duration(cpu["usage_usec"]) * time.Microsecond
+	stats.CPUUser = time.Duration(cpu["user_usec"]) * time.Microsecond
+	stats.CPUSystem = time.Duration(cpu["system_usec"]) * time.Microsecond
+
+	for file, value := range map[string]*int64{
+		"memory.current": &stats.MemoryCurrent,
+		"memory.peak":    &stats.MemoryPeak,
+	} {
+		b, err := ioutil.ReadFile(filepath.Join(c.Path, file))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return stats, err
+		}
+		if *value, err = strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64); err != nil {
+			return stats, fmt.Errorf("invalid %v %v", file, err)
+		}
+	}
+
+	// io.stat has a line of counters for every device
+	b, err := ioutil.ReadFile(filepath.Join(c.Path, "io.stat"))
+	if os.IsNotExist(err) {
+		return stats, nil
+	} else if err != nil {
+		return stats, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		for _, field := range strings.Fields(line) {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				continue
+			}
+			n, err := strconv.ParseUint(kv[1], 10, 64)
+			if err != nil {
+				continue
+			}
+			switch kv[0] {
+			case "rbytes":
+				stats.IOReadBytes += n
+			case "wbytes":
+				stats.IOWriteBytes += n
+			}
+		}
+	}
+	return stats, nil
+}
+
+// Remove deletes the cgroup, it must not contain any processes
+func (c *Cgroup) Remove() error {
+	return os.Remove(c.Path)
+}
+
+// readCgroupKeys reads a cgroup interface file of "key value" lines
+func readCgroupKeys(name string) (map[string]int64, error) {
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[string]int64)
+	for _, line := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		n, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v %v", name, err)
+		}
+		keys[fields[0]] = n
+	}
+	return keys, nil
+}
+
+// writeCgroupFile writes a single value to a cgroup interface file
+func writeCgroupFile(name string, value string) error {
+	return ioutil.WriteFile(name, []byte(value), 0644)
+}
@@ -0,0 +1,128 @@
+package core_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dboslee/job-worker/pkg/core"
+)
+
+func TestCgroupLimits(t *testing.T) {
+	parent, err := ioutil.TempDir("", "job-worker-cgroup-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(parent)
+
+	limits := &core.ResourceLimits{
+		CPUWeight:     50,
+		CPUMaxQuotaUS: 50000,
+		MemoryMax:     1 << 20,
+		MemoryHigh:    1 << 19,
+		IO:            []core.IOLimit{{Device: "8:0", ReadBPS: 1024, WriteIOPS: 10}},
+	}
+	cg, err := core.NewCgroup(parent, "job", limits)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	cases := []struct {
+		file  string
+		value string
+	}{
+		{"cpu.weight", "50"},
+		{"cpu.max", "50000 100000"},
+		{"memory.max", "1048576"},
+		{"memory.high", "524288"},
+		{"io.max", "8:0 rbps=1024 wiops=10"},
+	}
+	for _, tc := range cases {
+		b, err := ioutil.ReadFile(filepath.Join(cg.Path, tc.file))
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+			continue
+		}
+		if string(b) != tc.value {
+			t.Errorf("%v want: %q got: %q", tc.file, tc.value, string(b))
+		}
+	}
+}
+
+func TestCgroupStats(t *testing.T) {
+	dir, err := ioutil.TempDir("", "job-worker-cgroup-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"cpu.stat":       "usage_usec 3000\nuser_usec 2000\nsystem_usec 1000\nnr_periods 0\n",
+		"memory.current": "4096\n",
+		"io.stat":        "8:0 rbytes=100 wbytes=200 rios=1 wios=2\n8:16 rbytes=1 wbytes=2 rios=1 wios=1\n",
+	}
+	for name, value := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(value), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cg := &core.Cgroup{Path: dir}
+	stats, err := cg.Stats()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// memory.peak is missing on older kernels
+	want := core.CgroupStats{
+		CPUUsage:      time.Millisecond * 3,
+		CPUUser:       time.Millisecond * 2,
+		CPUSystem:     time.Millisecond,
+		MemoryCurrent: 4096,
+		IOReadBytes:   101,
+		IOWriteBytes:  202,
+	}
+	if stats != want {
+		t.Errorf("stats want: %+v got: %+v", want, stats)
+	}
+
+	os.Remove(filepath.Join(dir, "cpu.stat"))
+	if _, err := cg.Stats(); err == nil {
+		t.Errorf("expected error without cpu.stat")
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	cases := []struct {
+		limits core.ResourceLimits
+		valid  bool
+	}{
+		{core.ResourceLimits{}, true},
+		{core.ResourceLimits{CPUWeight: 100}, true},
+		{core.ResourceLimits{CPUWeight: 10001}, false},
+		{core.ResourceLimits{CPUMaxPeriodUS: 1000}, false},
+		{core.ResourceLimits{MemoryMax: -1}, false},
+		{core.ResourceLimits{IO: []core.IOLimit{{Device: "sda"}}}, false},
+	}
+
+	for _, tc := range cases {
+		err := tc.limits.Validate()
+		if (err == nil) != tc.valid {
+			t.Errorf("limits %+v valid want: %v got err: %v", tc.limits, tc.valid, err)
+		}
+	}
+}
+
+func TestCheckCgroupParent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "job-worker-cgroup-*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// A plain directory accepts every write so it must be rejected up front
+	if err := core.CheckCgroupParent(dir); err == nil {
+		t.Errorf("expected error for a directory outside cgroupfs")
+	}
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"compress/gzip"
+	"expvar"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+)
+
+// compressedBytesSaved is the disk space saved by compressing output segments, it
+// is published with expvar
+var compressedBytesSaved = expvar.NewInt("output_compressed_bytes_saved")
+
+// CompressedBytesSaved returns the disk space saved by compressing output segments since the server started
+func CompressedBytesSaved() int64 {
+	return compressedBytesSaved.Value()
+}
+
+// segmentFile is a segment opened for reading
+type segmentFile interface {
+	io.ReaderAt
+	io.Closer
+}
+
+// gzipSegment is a compressed segment decompressed as it is read so it is never held in
+// memory. Reads are expected to move forward, reading before the last read starts over.
+type gzipSegment struct {
+	f  *os.File
+	zr *gzip.Reader
+	// off is the offset in the decompressed segment of the next byte of zr
+	off int64
+}
+
+// ReadAt implements io.ReaderAt
+func (g *gzipSegment) ReadAt(p []byte, off int64) (int, error) {
+	if off < g.off {
+		if _, err := g.f.Seek(0, io.SeekStart); err != nil {
+			return 0, err
+		}
+		if err := g.zr.Reset(g.f); err != nil {
+			return 0, err
+		}
+		g.off = 0
+	}
+	if off > g.off {
+		n, err := io.CopyN(ioutil.Discard, g.zr, off-g.off)
+		g.off += n
+		if err != nil {
+			return 0, err
+		}
+	}
+	n, err := io.ReadFull(g.zr, p)
+	g.off += int64(n)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Close implements io.Closer
+func (g *gzipSegment) Close() error {
+	return g.f.Close()
+}
+
+// readSegment returns the chunks of a segment file, a compressed segment is
+// decompressed as it is read
+func readSegment(f *os.File, compressed bool) (segmentFile, error) {
+	if !compressed {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &gzipSegment{f: f, zr: zr}, nil
+}
+
+// compressFile writes a gzip copy of name to name.gz and returns its size, the
+// copy only appears once it is complete
+func compressFile(name string) (int64, error) {
+	in, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	tmp := name + ".gz.tmp"
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp)
+	defer out.Close()
+
+	zw := gzip.NewWriter(out)
+	if _, err = io.Copy(zw, in); err != nil {
+		return 0, err
+	}
+	if err = zw.Close(); err != nil {
+		return 0, err
+	}
+	if err = out.Sync(); err != nil {
+		return 0, err
+	}
+	info, err := out.Stat()
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), os.Rename(tmp, name+".gz")
+}
+
+// compress replaces a completed segment with a compressed copy
+func (o *OutputBuffer) compress(seg segment) {
+	defer o.compressing.Done()
+	name := segmentName(o.dir, seg.seq)
+	stored, err := compressFile(name)
+	if err != nil {
+		log.Printf("unable to compress output segment %v %v", name, err)
+		return
+	}
+
+	// Readers open the plain file while it is still listed so it is only removed after
+	o.mu.Lock()
+	found := false
+	for i := range o.segments {
+		if o.segments[i].seq == seg.seq {
+			o.segments[i].compressed = true
+			o.segments[i].stored = stored
+			found = true
+		}
+	}
+	o.mu.Unlock()
+
+	if found {
+		compressedBytesSaved.Add(seg.size - stored)
+	} else {
+		// The segment was rotated away while it was compressed
+		os.Remove(name + ".gz")
+	}
+	os.Remove(name)
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType is a step in the lifecycle of a job
+type EventType int
+
+const (
+	// EventCreated is published when a job is submitted
+	EventCreated EventType = iota + 1
+	// EventQueued is published when a job waits for a free slot
+	EventQueued
+	// EventStarted is published once the process of a job is running
+	EventStarted
+	// EventExited is published when a job finishes on its own
+	EventExited
+	// EventStopped is published when a job finishes after being stopped or cancelled
+	EventStopped
+	// EventOutputTruncated is published the first time output is dropped by the output limit
+	EventOutputTruncated
+)
+
+// String is a convienient way to convert an event type to string
+func (t EventType) String() string {
+	switch t {
+	case EventCreated:
+		return "created"
+	case EventQueued:
+		return "queued"
+	case EventStarted:
+		return "started"
+	case EventExited:
+		return "exited"
+	case EventStopped:
+		return "stopped"
+	case EventOutputTruncated:
+		return "output_truncated"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes a change to a job
+type Event struct {
+	// Seq increases by one with every event published on a bus
+	Seq      uint64
+	Type     EventType
+	JobID    string
+	ClientID string
+	Time     time.Time
+	// Status and ExitCode are the state of the job when the event was published
+	Status   JobStatus
+	ExitCode int
+}
+
+// DefaultEventHistory is the number of past events an EventBus replays to new subscribers
+const DefaultEventHistory = 1000
+
+// subscriberBuffer is the number of events a subscriber may fall behind before it is dropped
+const subscriberBuffer = 256
+
+// EventBus fans out job events to subscribers and keeps recent events so
+// subscribers can resume after the last event they saw
+type EventBus struct {
+	seq     uint64
+	history []Event
+	size    int
+	subs    map[*subscription]struct{}
+	mu      sync.Mutex
+}
+
+// subscription is a subscriber to an EventBus
+type subscription struct {
+	ch     chan Event
+	filter func(Event) bool
+}
+
+// NewEventBus creates an EventBus remembering the last history events
+func NewEventBus(history int) *EventBus {
+	return &EventBus{size: history, subs: make(map[*subscription]struct{})}
+}
+
+// Publish assigns the next seq to an event and sends it to every matching subscriber.
+// Subscribers that have fallen too far behind are dropped by closing their channel.
+func (b *EventBus) Publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.seq++
+	e.Seq = b.seq
+	b.history = append(b.history, e)
+	if len(b.history) > b.size {
+		b.history = b.history[len(b.history)-b.size:]
+	}
+	for sub := range b.subs {
+		if !sub.filter(e) {
+			continue
+		}
+		select {
+		case sub.ch <- e:
+		default:
+			delete(b.subs, sub)
+			close(sub.ch)
+		}
+	}
+}
+
+// Subscribe returns the events matching filter starting with any remembered events
+// after seq. The channel is closed if the subscriber falls behind, cancel ends the
+// subscription.
+func (b *EventBus) Subscribe(after uint64, filter func(Event) bool) (events <-chan Event, cancel func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var replay []Event
+	for _, e := range b.history {
+		if e.Seq > after && filter(e) {
+			replay = append(replay, e)
+		}
+	}
+	sub := &subscription{ch: make(chan Event, len(replay)+subscriberBuffer), filter: filter}
+	for _, e := range replay {
+		sub.ch <- e
+	}
+	b.subs[sub] = struct{}{}
+
+	cancel = func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subs[sub]; ok {
+			delete(b.subs, sub)
+			close(sub.ch)
+		}
+	}
+	return sub.ch, cancel
+}
@@ -0,0 +1,63 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/dboslee/job-worker/pkg/core"
+)
+
+func TestEventBus(t *testing.T) {
+	bus := core.NewEventBus(2)
+	all := func(core.Event) bool { return true }
+	events, cancel := bus.Subscribe(0, all)
+	defer cancel()
+	for _, id := range []string{"a", "b", "c"} {
+		bus.Publish(core.Event{Type: core.EventCreated, JobID: id})
+	}
+	for i, id := range []string{"a", "b", "c"} {
+		e := <-events
+		if e.JobID != id || e.Seq != uint64(i+1) {
+			t.Errorf("event want: %v %v got: %v %v", i+1, id, e.Seq, e.JobID)
+		}
+	}
+
+	// Only the remembered events after seq are replayed
+	replay, cancelReplay := bus.Subscribe(2, func(e core.Event) bool { return e.JobID != "b" })
+	defer cancelReplay()
+	if e := <-replay; e.JobID != "c" {
+		t.Errorf("expected replay of c got: %v", e.JobID)
+	}
+
+	// A subscriber that falls behind is dropped
+	slow, cancelSlow := bus.Subscribe(3, all)
+	defer cancelSlow()
+	for i := 0; i < 1000; i++ {
+		bus.Publish(core.Event{Type: core.EventStarted})
+	}
+	n := 0
+	for range slow {
+		n++
+	}
+	if n >= 1000 {
+		t.Errorf("expected slow subscriber to be dropped")
+	}
+}
+
+func TestJobEvents(t *testing.T) {
+	bus := core.NewEventBus(core.DefaultEventHistory)
+	job, _ := core.NewJob("test-client", "sleep", "5")
+	job.Cmd = mockExec("sleep", "5")
+	job.Events = bus
+	events, cancel := bus.Subscribe(0, func(e core.Event) bool { return e.JobID == job.ID })
+	defer cancel()
+
+	go job.Start()
+	if e := <-events; e.Type != core.EventStarted || e.Status != core.Running {
+		t.Errorf("expected started event got: %v %v", e.Type, e.Status)
+	}
+	job.Stop(nil, 0)
+	<-job.Done()
+	if e := <-events; e.Type != core.EventStopped || !e.Status.Finished() {
+		t.Errorf("expected stopped event got: %v %v", e.Type, e.Status)
+	}
+}
@@ -0,0 +1,448 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// compactMinRecords is the size of the log below which it is never compacted
+const compactMinRecords = 1000
+
+// FileStore is a JobStore persisted to an append only log. Every status change
+// appends a record of the job and the log is compacted to the latest record of
+// each job once it holds more than twice as many records as jobs.
+type FileStore struct {
+	path    string
+	f       *os.File
+	jobs    map[string]*Job
+	clients clientIndex
+	// specs are taken when pending jobs are added as their command changes once started
+	specs   map[string]*jobSpec
+	records int
+	mu      sync.RWMutex
+}
+
+// jobRecord is the state of a job stored in the log
+type jobRecord struct {
+	ID        string            `json:"id"`
+	ClientID  string            `json:"client_id"`
+	Command   string            `json:"command"`
+	Args      []string          `json:"args,omitempty"`
+	Image     string            `json:"image,omitempty"`
+	Isolation Isolation         `json:"isolation"`
+	Priority  int               `json:"priority,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	Status    string            `json:"status"`
+	ExitCode  int               `json:"exit_code"`
+	Error     string            `json:"error,omitempty"`
+	ErrorCode string            `json:"error_code,omitempty"`
+	// Output is the directory holding the output segments
+	Output string `json:"output"`
+	// OutputDropped is the number of output bytes dropped by the output limit
+	OutputDropped int64  `json:"output_dropped,omitempty"`
+	Workspace     string `json:"workspace,omitempty"`
+	// StartedAt and FinishedAt are the zero time until the job has started and finished
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// Signal is the number of the signal that terminated the job
+	Signal      int            `json:"signal,omitempty"`
+	Usage       *ResourceUsage `json:"usage,omitempty"`
+	CgroupStats *CgroupStats   `json:"cgroup_stats,omitempty"`
+	// Spec is only recorded while the job is pending
+	Spec *jobSpec `json:"spec,omitempty"`
+	// Deleted marks the removal of the job
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// jobSpec is what is needed to start a pending job again after a restart
+type jobSpec struct {
+	Env          []string            `json:"env,omitempty"`
+	Dir          string              `json:"dir,omitempty"`
+	Credential   *syscall.Credential `json:"credential,omitempty"`
+	CgroupParent string              `json:"cgroup_parent,omitempty"`
+	Limits       *ResourceLimits     `json:"limits,omitempty"`
+	Rootfs       string              `json:"rootfs,omitempty"`
+	Timeout      time.Duration       `json:"timeout,omitempty"`
+	StopSignal   int                 `json:"stop_signal,omitempty"`
+	GracePeriod  time.Duration       `json:"grace_period,omitempty"`
+	OutputLimit  OutputLimit         `json:"output_limit"`
+}
+
+// OpenFileStore opens the log at path creating it if needed. Jobs which were
+// running when the log was last written are marked Lost, pending jobs are restored
+// as pending to be submitted again unless they were attached to a stdin or terminal.
+func OpenFileStore(path string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	fs := &FileStore{
+		path:    path,
+		jobs:    make(map[string]*Job),
+		clients: make(clientIndex),
+		specs:   make(map[string]*jobSpec),
+	}
+	if err := fs.load(); err != nil {
+		return nil, err
+	}
+	// Rewrite the log so lost jobs are recorded and the log starts compacted
+	if err := fs.compact(); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// load replays the log keeping the latest record of each job
+func (fs *FileStore) load() error {
+	f, err := os.Open(fs.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	records := make(map[string]jobRecord)
+	var order []string
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF && len(line) == 0 {
+			break
+		} else if err != nil && err != io.EOF {
+			return err
+		}
+		var rec jobRecord
+		if err := json.Unmarshal(line, &rec); err != nil {
+			// A crash can leave the last record partly written
+			log.Printf("skipping invalid job record %v", err)
+			continue
+		}
+		if rec.Deleted {
+			delete(records, rec.ID)
+			continue
+		}
+		if _, ok := records[rec.ID]; !ok {
+			order = append(order, rec.ID)
+		}
+		records[rec.ID] = rec
+	}
+
+	for _, id := range order {
+		rec, ok := records[id]
+		if !ok {
+			continue
+		}
+		j, err := restoreJob(rec)
+		if err != nil {
+			return err
+		}
+		fs.jobs[id] = j
+		fs.clients.add(j)
+		if j.Status() == Pending {
+			fs.specs[id] = rec.Spec
+			j.Watch(fs.update)
+		}
+	}
+	return nil
+}
+
+// restoreJob recreates a job from its record, pending jobs with a spec can be started again
+func restoreJob(rec jobRecord) (*Job, error) {
+	status, err := ParseJobStatus(rec.Status)
+	if err != nil {
+		return nil, err
+	}
+	j := &Job{
+		ID:          rec.ID,
+		ClientID:    rec.ClientID,
+		Command:     rec.Command,
+		Args:        rec.Args,
+		Cmd:         &exec.Cmd{Path: rec.Command, Args: append([]string{rec.Command}, rec.Args...)},
+		Image:       rec.Image,
+		Isolation:   rec.Isolation,
+		Priority:    rec.Priority,
+		Labels:      rec.Labels,
+		CreatedAt:   rec.CreatedAt,
+		Workspace:   rec.Workspace,
+		status:      status,
+		exitCode:    rec.ExitCode,
+		startedAt:   rec.StartedAt,
+		finishedAt:  rec.FinishedAt,
+		signal:      syscall.Signal(rec.Signal),
+		usage:       rec.Usage,
+		cgroupStats: rec.CgroupStats,
+		done:        make(chan struct{}),
+	}
+	// Pending jobs are only requeued if they can be started again as they were submitted
+	requeue := status == Pending && rec.Spec != nil
+	if requeue {
+		j.restoreSpec(rec.Spec)
+	} else {
+		close(j.done)
+	}
+	if rec.Error != "" {
+		j.err = &JobError{Code: ParseErrorCode(rec.ErrorCode), Message: rec.Error}
+	}
+	if !status.Finished() && !requeue {
+		j.status = Lost
+		j.err = &JobError{Code: ErrorCodeLost, Message: fmt.Sprintf("server stopped while the job was %v", status)}
+		j.finishedAt = time.Now()
+	}
+
+	j.OutputBuf, err = OpenOutputBuffer(rec.Output)
+	if err != nil {
+		log.Printf("unable to open output of job %v %v", rec.ID, err)
+		j.OutputBuf = &OutputBuffer{dir: rec.Output, segments: []segment{{}}}
+	}
+	j.OutputBuf.dropped = rec.OutputDropped
+	return j, nil
+}
+
+// restoreSpec recreates the command of a pending job from its spec
+func (j *Job) restoreSpec(spec *jobSpec) {
+	j.Cmd = exec.Command(j.Command, j.Args...)
+	j.Cmd.Env = spec.Env
+	j.Cmd.Dir = spec.Dir
+	j.Cmd.SysProcAttr = &syscall.SysProcAttr{Credential: spec.Credential}
+	j.CgroupParent = spec.CgroupParent
+	j.Limits = spec.Limits
+	j.Rootfs = spec.Rootfs
+	j.Timeout = spec.Timeout
+	if spec.StopSignal != 0 {
+		j.StopSignal = syscall.Signal(spec.StopSignal)
+	}
+	j.GracePeriod = spec.GracePeriod
+	j.OutputLimit = spec.OutputLimit
+	j.exitCode = -1
+}
+
+// newJobSpec captures what is needed to start a job again, jobs attached to a stdin
+// or terminal can not be restarted without their client so they have none
+func newJobSpec(j *Job) *jobSpec {
+	if j.stdin != nil {
+		return nil
+	}
+	spec := &jobSpec{
+		Env:          j.Cmd.Env,
+		Dir:          j.Cmd.Dir,
+		CgroupParent: j.CgroupParent,
+		Limits:       j.Limits,
+		Rootfs:       j.Rootfs,
+		Timeout:      j.Timeout,
+		GracePeriod:  j.GracePeriod,
+		OutputLimit:  j.OutputLimit,
+	}
+	if attr := j.Cmd.SysProcAttr; attr != nil {
+		spec.Credential = attr.Credential
+	}
+	if sig, ok := j.StopSignal.(syscall.Signal); ok {
+		spec.StopSignal = int(sig)
+	}
+	return spec
+}
+
+// newJobRecord captures the current state of a job
+func newJobRecord(j *Job) jobRecord {
+	rec := jobRecord{
+		ID:          j.ID,
+		ClientID:    j.ClientID,
+		Command:     j.Command,
+		Args:        j.Args,
+		Image:       j.Image,
+		Isolation:   j.Isolation,
+		Priority:    j.Priority,
+		Labels:      j.Labels,
+		CreatedAt:   j.CreatedAt,
+		Status:      j.Status().String(),
+		ExitCode:    j.ExitCode(),
+		Workspace:   j.Workspace,
+		StartedAt:   j.StartedAt(),
+		FinishedAt:  j.FinishedAt(),
+		Signal:      int(j.TermSignal()),
+		Usage:       j.Usage(),
+		CgroupStats: j.CgroupStats(),
+	}
+	if err := j.Error(); err != nil {
+		rec.Error = err.Error()
+		var jobErr *JobError
+		if errors.As(err, &jobErr) {
+			rec.ErrorCode = jobErr.Code.String()
+		}
+	}
+	if j.OutputBuf != nil {
+		rec.Output = j.OutputBuf.Dir()
+		rec.OutputDropped = j.OutputBuf.Truncated()
+	}
+	return rec
+}
+
+// Add adds a job to the store and records every later status change
+func (fs *FileStore) Add(j *Job) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	// The job must be in the map in case the write compacts the log
+	fs.jobs[j.ID] = j
+	if j.Status() == Pending {
+		fs.specs[j.ID] = newJobSpec(j)
+	}
+	if err := fs.write(j); err != nil {
+		delete(fs.jobs, j.ID)
+		delete(fs.specs, j.ID)
+		return err
+	}
+	fs.clients.add(j)
+	j.Watch(fs.update)
+	return nil
+}
+
+// Get returns a job
+func (fs *FileStore) Get(id string) (j *Job, ok bool) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	j, ok = fs.jobs[id]
+	return j, ok
+}
+
+// Delete removes a job from the store
+func (fs *FileStore) Delete(id string) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	j, ok := fs.jobs[id]
+	if !ok {
+		return nil
+	}
+	b, err := json.Marshal(jobRecord{ID: id, Deleted: true})
+	if err != nil {
+		return err
+	}
+	if err = fs.append(b); err != nil {
+		return err
+	}
+	delete(fs.jobs, id)
+	delete(fs.specs, id)
+	fs.clients.remove(j)
+	return nil
+}
+
+// List returns every job in the store
+func (fs *FileStore) List() []*Job {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	jobs := make([]*Job, 0, len(fs.jobs))
+	for _, j := range fs.jobs {
+		jobs = append(jobs, j)
+	}
+	return jobs
+}
+
+// ListClient returns the jobs of a client
+func (fs *FileStore) ListClient(clientID string) []*Job {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return fs.clients.list(clientID)
+}
+
+// Close closes the log
+func (fs *FileStore) Close() error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.f.Close()
+}
+
+// update records the new status of a job
+func (fs *FileStore) update(j *Job) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	// Deleted jobs may still be running
+	if _, ok := fs.jobs[j.ID]; !ok {
+		return
+	}
+	if err := fs.write(j); err != nil {
+		log.Printf("unable to record job %v %v", j.ID, err)
+	}
+}
+
+// write appends a record of the job, fs.mu must be held.
+// The record is taken under the lock so later records are never older.
+func (fs *FileStore) write(j *Job) error {
+	b, err := json.Marshal(fs.record(j))
+	if err != nil {
+		return err
+	}
+	return fs.append(b)
+}
+
+// record captures the current state of a job with its spec while it is pending, fs.mu must be held
+func (fs *FileStore) record(j *Job) jobRecord {
+	rec := newJobRecord(j)
+	if rec.Status == Pending.String() {
+		rec.Spec = fs.specs[j.ID]
+	} else {
+		delete(fs.specs, j.ID)
+	}
+	return rec
+}
+
+// append adds a record to the log compacting it when needed, fs.mu must be held
+func (fs *FileStore) append(b []byte) error {
+	if _, err := fs.f.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	fs.records++
+	if fs.records > compactMinRecords && fs.records > 2*len(fs.jobs) {
+		return fs.compact()
+	}
+	return nil
+}
+
+// compact replaces the log with the latest record of each job, fs.mu must be held
+func (fs *FileStore) compact() error {
+	// Pending jobs record their environment so the log is only readable by the server
+	tmp, err := os.OpenFile(fs.path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	for _, j := range fs.jobs {
+		b, err := json.Marshal(fs.record(j))
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		w.Write(append(b, '\n'))
+	}
+	if err = w.Flush(); err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmp.Name(), fs.path); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(fs.path, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	if fs.f != nil {
+		fs.f.Close()
+	}
+	fs.f = f
+	fs.records = len(fs.jobs)
+	return nil
+}
@@ -0,0 +1,181 @@
+package core_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dboslee/job-worker/pkg/core"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.log")
+	store, err := core.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	done, _ := core.NewJob("test-client", "echo", "hello")
+	done.Cmd = mockExec("echo", "hello")
+	if err = store.Add(done); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	failed, _ := core.NewJob("test-client", "exit", "3")
+	failed.Cmd = mockExec("exit", "3")
+	store.Add(failed)
+	running, _ := core.NewJob("test-client", "sleep", "5")
+	running.Cmd = mockExec("sleep", "5")
+	store.Add(running)
+	defer running.Kill()
+
+	done.Start()
+	failed.Start()
+	go running.Start()
+	waitStatus(t, running, core.Running)
+	store.Close()
+
+	store, err = core.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	tests := []struct {
+		job     *core.Job
+		status  core.JobStatus
+		code    int
+		errCode core.ErrorCode
+		output  string
+	}{
+		{done, core.Complete, 0, 0, "hello\n"},
+		{failed, core.Failed, 3, core.ErrorCodeExitStatus, ""},
+		{running, core.Lost, -1, core.ErrorCodeLost, ""},
+	}
+	for _, test := range tests {
+		j, ok := store.Get(test.job.ID)
+		if !ok {
+			t.Fatalf("job %v not restored", test.job.Args)
+		}
+		if j.Status() != test.status || j.ExitCode() != test.code {
+			t.Errorf("%v: want: %v %v got: %v %v", j.Args, test.status, test.code, j.Status(), j.ExitCode())
+		}
+		var jobErr *core.JobError
+		if test.status != core.Complete && (!errors.As(j.Error(), &jobErr) || jobErr.Code != test.errCode) {
+			t.Errorf("%v: expected a %v error got: %v", j.Args, test.errCode, j.Error())
+		}
+		r, err := j.OutputBuf.NewReader()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		b, _ := ioutil.ReadAll(r)
+		r.Close()
+		if string(b) != test.output {
+			t.Errorf("%v: output want: %q got: %q", j.Args, test.output, b)
+		}
+	}
+
+	// The log is compacted to one record per job when it is opened
+	b, _ := ioutil.ReadFile(path)
+	if lines := bytes.Count(b, []byte("\n")); lines != len(tests) {
+		t.Errorf("expected %v records got: %v", len(tests), lines)
+	}
+
+	// Deleted jobs stay deleted
+	if err = store.Delete(done.ID); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	store.Close()
+	store, err = core.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer store.Close()
+	if _, ok := store.Get(done.ID); ok {
+		t.Errorf("deleted job restored")
+	}
+	if jobs := store.List(); len(jobs) != len(tests)-1 {
+		t.Errorf("expected %v jobs got: %v", len(tests)-1, len(jobs))
+	}
+	if jobs := store.ListClient("test-client"); len(jobs) != len(tests)-1 {
+		t.Errorf("expected %v jobs of the client got: %v", len(tests)-1, len(jobs))
+	}
+	if jobs := store.ListClient("other-client"); len(jobs) != 0 {
+		t.Errorf("expected no jobs of another client got: %v", len(jobs))
+	}
+}
+
+func TestFileStoreLargeRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.log")
+	store, err := core.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	large, _ := core.NewJob("test-client", "echo", strings.Repeat("a", 2<<20))
+	if err = store.Add(large); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	store.Close()
+
+	// A partly written record is skipped
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	f.WriteString(`{"id":"partial`)
+	f.Close()
+
+	store, err = core.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer store.Close()
+	if jobs := store.List(); len(jobs) != 1 || jobs[0].ID != large.ID {
+		t.Errorf("expected the large job to be restored got: %v jobs", len(jobs))
+	}
+}
+
+func TestFileStoreRequeue(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.log")
+	store, err := core.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	pending, _ := core.NewJob("test-client", "sh", "-c", "echo $GREETING")
+	pending.Cmd.Env = []string{"GREETING=hello"}
+	pending.Timeout = time.Minute
+	store.Add(pending)
+	// A job fed from a client stdin can not be started again without it
+	attached, _ := core.NewJob("test-client", "cat")
+	attached.OpenStdin()
+	store.Add(attached)
+	store.Close()
+
+	store, err = core.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer store.Close()
+	if j, _ := store.Get(attached.ID); j.Status() != core.Lost {
+		t.Errorf("attached job want: %v got: %v", core.Lost, j.Status())
+	}
+	j, _ := store.Get(pending.ID)
+	if j.Status() != core.Pending || j.Timeout != time.Minute {
+		t.Fatalf("expected a pending job with its timeout got: %v %v", j.Status(), j.Timeout)
+	}
+
+	// The restored job runs as it was submitted and its status is recorded
+	if err = j.Start(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	waitOutput(t, j, "hello\n")
+	store.Close()
+	store, err = core.OpenFileStore(path)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer store.Close()
+	if j, _ := store.Get(pending.ID); j.Status() != core.Complete {
+		t.Errorf("expected the requeued job to be recorded complete got: %v", j.Status())
+	}
+}
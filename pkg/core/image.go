@@ -0,0 +1,172 @@
+package core
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultImageDir is where rootfs tarballs are extracted
+const DefaultImageDir = "/var/lib/job-worker/images"
+
+// ImageStore resolves rootfs images registered on the server to directories.
+// Images are either a directory or a tar archive which is extracted on first use.
+type ImageStore struct {
+	dir    string
+	images map[string]string
+	ready  map[string]string
+	mu     sync.Mutex
+}
+
+// NewImageStore creates an ImageStore for the named images, tarballs are extracted under dir
+func NewImageStore(dir string, images map[string]string) *ImageStore {
+	return &ImageStore{
+		dir:    dir,
+		images: images,
+		ready:  make(map[string]string),
+	}
+}
+
+// Path returns the rootfs directory of the named image
+func (is *ImageStore) Path(name string) (string, error) {
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	if path, ok := is.ready[name]; ok {
+		return path, nil
+	}
+	src, ok := is.images[name]
+	if !ok {
+		return "", fmt.Errorf("unknown image %q", name)
+	}
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return "", err
+	}
+	path := src
+	if !info.IsDir() {
+		path = filepath.Join(is.dir, name)
+		if err = extractImage(src, path); err != nil {
+			os.RemoveAll(path)
+			return "", fmt.Errorf("unable to extract image %q %v", name, err)
+		}
+	}
+
+	// Mount points the init process needs must exist before the base is read only
+	for _, dir := range []string{"proc", "dev", "tmp", "workspace"} {
+		if err = os.MkdirAll(filepath.Join(path, dir), 0755); err != nil {
+			return "", err
+		}
+	}
+	is.ready[name] = path
+	return path, nil
+}
+
+// extractImage extracts a tar or tar.gz archive into dir
+func extractImage(archive string, dir string) error {
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(archive, ".gz") || strings.HasSuffix(archive, ".tgz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	if err = os.RemoveAll(dir); err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		name := filepath.Join(dir, filepath.Clean("/"+hdr.Name))
+		if err = checkParents(dir, name); err != nil {
+			return err
+		}
+		mode := os.FileMode(hdr.Mode).Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(name, mode)
+		case tar.TypeReg:
+			err = extractFile(tr, name, mode)
+		case tar.TypeSymlink:
+			err = os.Symlink(hdr.Linkname, name)
+		case tar.TypeLink:
+			err = os.Link(filepath.Join(dir, filepath.Clean("/"+hdr.Linkname)), name)
+		default:
+			// Device nodes and fifos are provided by the init process
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// checkParents stops an archive from writing outside dir through a symlink it created earlier
+func checkParents(dir string, name string) error {
+	rel, err := filepath.Rel(dir, filepath.Dir(name))
+	if err != nil {
+		return err
+	}
+	path := dir
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if part == "." {
+			continue
+		}
+		path = filepath.Join(path, part)
+		info, err := os.Lstat(path)
+		if os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("refusing to extract %q through symlink", name)
+		}
+	}
+	return nil
+}
+
+// extractFile writes a single regular file from a tar archive
+func extractFile(r io.Reader, name string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	// Replace rather than follow anything already extracted at name
+	if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,101 @@
+package core_test
+
+import (
+	"archive/tar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dboslee/job-worker/pkg/core"
+)
+
+type tarEntry struct {
+	name     string
+	typeflag byte
+	body     string
+	linkname string
+}
+
+// mockImage writes a tar archive containing entries and returns its path
+func mockImage(t *testing.T, dir string, entries []tarEntry) string {
+	f, err := os.Create(filepath.Join(dir, "image.tar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	for _, e := range entries {
+		hdr := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Mode:     0755,
+			Size:     int64(len(e.body)),
+			Linkname: e.linkname,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+func TestImageExtract(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "job-worker-image-*")
+	defer os.RemoveAll(dir)
+
+	archive := mockImage(t, dir, []tarEntry{
+		{name: "bin/", typeflag: tar.TypeDir},
+		{name: "bin/tool", typeflag: tar.TypeReg, body: "tool"},
+		{name: "usr", typeflag: tar.TypeSymlink, linkname: "bin"},
+	})
+	images := core.NewImageStore(filepath.Join(dir, "images"), map[string]string{"test": archive})
+	path, err := images.Path("test")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(path, "usr", "tool"))
+	if err != nil || string(b) != "tool" {
+		t.Errorf("unexpected tool contents %q err: %v", b, err)
+	}
+	for _, mount := range []string{"proc", "dev", "tmp", "workspace"} {
+		if _, err := os.Stat(filepath.Join(path, mount)); err != nil {
+			t.Errorf("expected %v to exist got: %v", mount, err)
+		}
+	}
+
+	if _, err := images.Path("missing"); err == nil {
+		t.Errorf("expected error for unknown image")
+	}
+}
+
+func TestImageExtractEscape(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "job-worker-image-*")
+	defer os.RemoveAll(dir)
+	outside := filepath.Join(dir, "outside")
+	os.Mkdir(outside, 0755)
+
+	archive := mockImage(t, dir, []tarEntry{
+		{name: "../../escape", typeflag: tar.TypeReg, body: "x"},
+		{name: "link", typeflag: tar.TypeSymlink, linkname: outside},
+		{name: "link/escape", typeflag: tar.TypeReg, body: "x"},
+	})
+	images := core.NewImageStore(filepath.Join(dir, "images"), map[string]string{"test": archive})
+	if _, err := images.Path("test"); err == nil {
+		t.Errorf("expected error extracting through symlink")
+	}
+	if _, err := os.Stat(filepath.Join(outside, "escape")); err == nil {
+		t.Errorf("archive wrote outside the image directory")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape")); err == nil {
+		t.Errorf("archive wrote outside the image directory")
+	}
+}
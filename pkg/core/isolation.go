@@ -0,0 +1,490 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// InitName is the argv[0] used when the server re-executes itself as a job init process
+const InitName = "job-worker-init"
+
+// initReportFd is the pipe the init process writes an initReport to once the command has
+// started or could not be, and another with the wait status of the command when it exits
+const initReportFd = 3
+
+// initResumeFd is the pipe the init process waits on for a byte before it starts the
+// command, which is sent once the init process has been moved into the job cgroup
+const initResumeFd = 4
+
+// namespaceFlags are the namespaces created for the init process of an isolated job
+const namespaceFlags = syscall.CLONE_NEWPID |
+	syscall.CLONE_NEWNS |
+	syscall.CLONE_NEWUTS |
+	syscall.CLONE_NEWIPC |
+	syscall.CLONE_NEWNET
+
+// Isolation selects how a job is isolated from the host
+type Isolation int
+
+const (
+	// IsolationNone runs the job directly on the host
+	IsolationNone Isolation = iota
+	// IsolationNamespaces runs the job in new pid, mount, uts, ipc and network namespaces
+	IsolationNamespaces
+)
+
+// String is a convienient way to convert an isolation mode to string
+func (i Isolation) String() string {
+	switch i {
+	case IsolationNamespaces:
+		return "namespaces"
+	default:
+		return "none"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (i Isolation) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (i *Isolation) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "none":
+		*i = IsolationNone
+	case "namespaces":
+		*i = IsolationNamespaces
+	default:
+		return fmt.Errorf("unknown isolation %q", text)
+	}
+	return nil
+}
+
+// initConfig is passed to the init process as json in its first argument
+type initConfig struct {
+	// Namespaces runs the init process in new namespaces, otherwise it only reaps the job on the host
+	Namespaces bool   `json:"namespaces,omitempty"`
+	Hostname   string `json:"hostname"`
+	// Rootfs is pivoted to when set, otherwise the host filesystem is used
+	Rootfs string `json:"rootfs,omitempty"`
+	// Workspace is bind mounted writable at /workspace inside Rootfs
+	Workspace string `json:"workspace,omitempty"`
+	// Dir is the working directory of the command inside the namespaces
+	Dir string `json:"dir,omitempty"`
+	// Credential is the user the command runs as, the init process stays root to set up the namespaces
+	Credential *syscall.Credential `json:"credential,omitempty"`
+	// Tty makes the command a session leader controlling the terminal on its stdin
+	Tty bool `json:"tty,omitempty"`
+}
+
+// initReport is a message from the init process on its report pipe
+type initReport struct {
+	Started bool       `json:"started,omitempty"`
+	Error   *initError `json:"error,omitempty"`
+	// Status is the wait status of the command, the exit code of the init process can not
+	// tell a command killed by a signal from one exiting with a code above 128
+	Status *syscall.WaitStatus `json:"status,omitempty"`
+}
+
+// initReportFile is the report pipe inside the init process
+var initReportFile *os.File
+
+// writeInitReport sends a report to the server from inside the init process
+func writeInitReport(r initReport) {
+	b, _ := json.Marshal(r)
+	initReportFile.Write(append(b, '\n'))
+}
+
+// initOp is the step of starting the command an initError comes from
+type initOp string
+
+const (
+	// initOpSetup is preparing the init process and its namespaces before the command
+	initOpSetup initOp = "setup"
+	// initOpLookup is finding the command
+	initOpLookup initOp = "lookup"
+	// initOpChdir is changing to the working directory of the command
+	initOpChdir initOp = "chdir"
+	// initOpExec is executing the command
+	initOpExec initOp = "exec"
+)
+
+// initError is why the init process could not start the command
+type initError struct {
+	Op      initOp        `json:"op"`
+	Path    string        `json:"path,omitempty"`
+	Message string        `json:"message"`
+	Errno   syscall.Errno `json:"errno,omitempty"`
+}
+
+// Error returns the message of the error
+func (e *initError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the errno so the error can be compared with errors.Is
+func (e *initError) Unwrap() error {
+	if e.Errno == 0 {
+		return nil
+	}
+	return e.Errno
+}
+
+// initCommand returns a command which runs cmd through the init process, in new namespaces
+// when config.Namespaces is set, the read end of its report pipe which is read with waitInitStart
+// and waitInitStatus, and the write end of its resume pipe which is written with resumeInit
+func initCommand(cmd *exec.Cmd, config initConfig) (*exec.Cmd, *os.File, *os.File, error) {
+	// The init process looks up the command and reports if it is not found,
+	// inside the rootfs when one is used
+	path := cmd.Path
+	if config.Rootfs != "" {
+		path = cmd.Args[0]
+	}
+	if config.Dir == "" {
+		config.Dir = cmd.Dir
+	}
+
+	attr := &syscall.SysProcAttr{}
+	if cmd.SysProcAttr != nil {
+		*attr = *cmd.SysProcAttr
+		config.Credential = attr.Credential
+		attr.Credential = nil
+	}
+	b, err := json.Marshal(config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if config.Namespaces {
+		attr.Cloneflags |= namespaceFlags
+		// The init process takes the rest of the namespace down with it
+		attr.Pdeathsig = syscall.SIGKILL
+	}
+
+	report, reportWrite, err := os.Pipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	resumeRead, resume, err := os.Pipe()
+	if err != nil {
+		report.Close()
+		reportWrite.Close()
+		return nil, nil, nil, err
+	}
+	return &exec.Cmd{
+		Path:        "/proc/self/exe",
+		Args:        append([]string{InitName, string(b), path}, cmd.Args...),
+		Env:         cmd.Env,
+		Stdin:       cmd.Stdin,
+		Stdout:      cmd.Stdout,
+		Stderr:      cmd.Stderr,
+		ExtraFiles:  []*os.File{reportWrite, resumeRead},
+		SysProcAttr: attr,
+	}, report, resume, nil
+}
+
+// CheckNamespaces checks isolated jobs can be started by starting the init process in
+// new namespaces without a command, which exits straight away
+func CheckNamespaces() error {
+	cmd := &exec.Cmd{
+		Path:        "/proc/self/exe",
+		Args:        []string{InitName},
+		SysProcAttr: &syscall.SysProcAttr{Cloneflags: namespaceFlags},
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("unable to create namespaces %v", err)
+	}
+	cmd.Wait()
+	return nil
+}
+
+// waitInitStart waits for the init process to start the command and returns why it
+// could not. The write end of the report pipe must be closed once cmd is started.
+func waitInitStart(report *json.Decoder) error {
+	var r initReport
+	if err := report.Decode(&r); err == io.EOF {
+		return fmt.Errorf("init process exited before starting the command")
+	} else if err != nil {
+		return fmt.Errorf("invalid init report %v", err)
+	}
+	if r.Error != nil {
+		return r.Error
+	}
+	return nil
+}
+
+// waitInitStatus returns the wait status of the command reported by the init process
+// once it has exited, there is none if the init process was killed first
+func waitInitStatus(report *json.Decoder) (syscall.WaitStatus, bool) {
+	var r initReport
+	if err := report.Decode(&r); err != nil || r.Status == nil {
+		return 0, false
+	}
+	return *r.Status, true
+}
+
+// resumeInit lets the init process start the command and closes the resume pipe
+func resumeInit(resume *os.File) error {
+	defer resume.Close()
+	_, err := resume.Write([]byte{0})
+	return err
+}
+
+// waitResume blocks the init process until the server resumes it. The pipe is closed
+// without a byte if the server could not place the init process in the job cgroup.
+func waitResume() error {
+	resume := os.NewFile(initResumeFd, "resume")
+	defer resume.Close()
+	b := make([]byte, 1)
+	if n, _ := resume.Read(b); n == 0 {
+		return fmt.Errorf("init process was not resumed")
+	}
+	return nil
+}
+
+// initFailed reports why the command could not be started on the report pipe and
+// returns the exit code of the init process
+func initFailed(op initOp, path string, err error) int {
+	fmt.Fprintln(os.Stderr, err)
+	ie := &initError{Op: op, Path: path, Message: err.Error()}
+	if !errors.As(err, &ie.Errno) && errors.Is(err, exec.ErrNotFound) {
+		ie.Errno = syscall.ENOENT
+	}
+	writeInitReport(initReport{Error: ie})
+	return 127
+}
+
+// RunInit runs the job init process and exits if the program was started as one.
+// It must be called at the very start of main by any program that runs jobs.
+func RunInit() {
+	if os.Args[0] != InitName {
+		return
+	}
+	// The command must not inherit the report or resume pipes
+	syscall.CloseOnExec(initReportFd)
+	syscall.CloseOnExec(initResumeFd)
+	initReportFile = os.NewFile(initReportFd, "report")
+	if len(os.Args) < 4 {
+		os.Exit(initFailed(initOpSetup, "", fmt.Errorf("usage: "+InitName+" <config> <path> <args>")))
+	}
+	var config initConfig
+	if err := json.Unmarshal([]byte(os.Args[1]), &config); err != nil {
+		os.Exit(initFailed(initOpSetup, "", fmt.Errorf("invalid init config %v", err)))
+	}
+	// Nothing is started until the init process is in the job cgroup so every
+	// descendant is accounted and limited from its first instruction
+	if err := waitResume(); err != nil {
+		os.Exit(initFailed(initOpSetup, "", err))
+	}
+	// Orphaned descendants are reparented to the init process even if they leave the
+	// process group or session of the command, so every one of them is reaped here
+	becomeSubreaper()
+	if config.Namespaces {
+		if err := setupNamespaces(config); err != nil {
+			os.Exit(initFailed(initOpSetup, "", fmt.Errorf("unable to setup namespaces %v", err)))
+		}
+	}
+	os.Exit(runInit(config, os.Args[2], os.Args[3:]))
+}
+
+// setupNamespaces prepares the namespaces from inside the init process
+func setupNamespaces(config initConfig) error {
+	if err := syscall.Sethostname([]byte(config.Hostname)); err != nil {
+		return err
+	}
+	// Keep mount changes from propagating back to the host
+	if err := syscall.Mount("", "/", "", syscall.MS_REC|syscall.MS_PRIVATE, ""); err != nil {
+		return err
+	}
+	if err := loopbackUp(); err != nil {
+		return err
+	}
+	if config.Rootfs != "" {
+		return pivotRoot(config.Rootfs, config.Workspace)
+	}
+	return mountProc("/proc")
+}
+
+// mountProc mounts a fresh proc which only shows processes in the new pid namespace
+func mountProc(target string) error {
+	flags := uintptr(syscall.MS_NOSUID | syscall.MS_NODEV | syscall.MS_NOEXEC)
+	return syscall.Mount("proc", target, "proc", flags, "")
+}
+
+// pivotRoot makes a read only bind of rootfs the root filesystem with the workspace
+// mounted writable at /workspace and fresh /proc, /tmp and /dev mounts
+func pivotRoot(rootfs string, workspace string) error {
+	// pivot_root requires the new root to be a mount point
+	if err := syscall.Mount(rootfs, rootfs, "", syscall.MS_BIND|syscall.MS_REC, ""); err != nil {
+		return err
+	}
+	if workspace != "" {
+		target := filepath.Join(rootfs, "workspace")
+		if err := syscall.Mount(workspace, target, "", syscall.MS_BIND, ""); err != nil {
+			return err
+		}
+	}
+	flags := uintptr(syscall.MS_BIND | syscall.MS_REMOUNT | syscall.MS_RDONLY)
+	if err := syscall.Mount("", rootfs, "", flags, ""); err != nil {
+		return err
+	}
+
+	if err := mountProc(filepath.Join(rootfs, "proc")); err != nil {
+		return err
+	}
+	flags = uintptr(syscall.MS_NOSUID | syscall.MS_NODEV)
+	if err := syscall.Mount("tmpfs", filepath.Join(rootfs, "tmp"), "tmpfs", flags, "mode=1777"); err != nil {
+		return err
+	}
+	if err := syscall.Mount("tmpfs", filepath.Join(rootfs, "dev"), "tmpfs", syscall.MS_NOSUID, "mode=755"); err != nil {
+		return err
+	}
+	for _, dev := range []string{"null", "zero", "full", "random", "urandom", "tty"} {
+		target := filepath.Join(rootfs, "dev", dev)
+		f, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		f.Close()
+		if err = syscall.Mount("/dev/"+dev, target, "", syscall.MS_BIND, ""); err != nil {
+			return err
+		}
+	}
+
+	// Stack the new root on top of the old one and detach the old root
+	if err := os.Chdir(rootfs); err != nil {
+		return err
+	}
+	if err := syscall.PivotRoot(".", "."); err != nil {
+		return err
+	}
+	if err := syscall.Unmount(".", syscall.MNT_DETACH); err != nil {
+		return err
+	}
+	return os.Chdir("/")
+}
+
+// loopbackUp brings up the loopback interface of the new network namespace
+func loopbackUp() error {
+	fd, err := syscall.Socket(syscall.AF_INET, syscall.SOCK_DGRAM, 0)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+
+	var ifr struct {
+		name  [syscall.IFNAMSIZ]byte
+		flags uint16
+		_     [22]byte
+	}
+	copy(ifr.name[:], "lo")
+	ptr := uintptr(unsafe.Pointer(&ifr))
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCGIFFLAGS, ptr); errno != 0 {
+		return errno
+	}
+	ifr.flags |= syscall.IFF_UP
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), syscall.SIOCSIFFLAGS, ptr); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// runInit starts the command as a child, forwards signals to every descendant and reaps
+// orphans until every descendant has exited. The wait status of the command is reported
+// to the server and its exit code is returned, or 128+n if it was killed by signal n.
+func runInit(config initConfig, path string, args []string) int {
+	if !strings.Contains(path, "/") {
+		lp, err := exec.LookPath(path)
+		if err != nil {
+			return initFailed(initOpLookup, path, err)
+		}
+		path = lp
+	} else if _, err := os.Stat(path); err != nil {
+		return initFailed(initOpLookup, path, err)
+	}
+	dir := config.Dir
+	if dir == "" && config.Rootfs != "" {
+		dir = "/workspace"
+	}
+	// Starting the command fails with the same errno whether the working directory or the
+	// command is missing, so the directory is checked first
+	if dir != "" {
+		if info, err := os.Stat(dir); err != nil {
+			return initFailed(initOpChdir, dir, err)
+		} else if !info.IsDir() {
+			return initFailed(initOpChdir, dir, &os.PathError{Op: "chdir", Path: dir, Err: syscall.ENOTDIR})
+		}
+	}
+	cmd := &exec.Cmd{
+		Path:   path,
+		Args:   args,
+		Dir:    dir,
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+		// Forwarded signals reach every process the command starts
+		SysProcAttr: &syscall.SysProcAttr{
+			Setpgid:    !config.Tty,
+			Setsid:     config.Tty,
+			Setctty:    config.Tty,
+			Credential: config.Credential,
+		},
+	}
+
+	// Signals are only delivered to a namespace init if it handles them
+	sigs := make(chan os.Signal, 16)
+	signal.Notify(sigs)
+	if err := cmd.Start(); err != nil {
+		return initFailed(initOpExec, path, err)
+	}
+	writeInitReport(initReport{Started: true})
+	go func() {
+		for sig := range sigs {
+			if sig == syscall.SIGCHLD || sig == syscall.SIGURG {
+				continue
+			}
+			// Descendants that left the process group of the command are signalled too
+			syscall.Kill(-cmd.Process.Pid, sig.(syscall.Signal))
+			for _, pid := range descendants(os.Getpid()) {
+				syscall.Kill(pid, sig.(syscall.Signal))
+			}
+		}
+	}()
+
+	// Every orphaned descendant is reparented here, keep reaping until they
+	// have all exited so the job finishes with its last descendant
+	code := 127
+	var status *syscall.WaitStatus
+	for {
+		var ws syscall.WaitStatus
+		pid, err := syscall.Wait4(-1, &ws, 0, nil)
+		if err == syscall.EINTR {
+			continue
+		} else if err == syscall.ECHILD {
+			break
+		} else if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 127
+		}
+		if pid != cmd.Process.Pid {
+			continue
+		}
+		status = &ws
+		if ws.Signaled() {
+			code = 128 + int(ws.Signal())
+		} else {
+			code = ws.ExitStatus()
+		}
+	}
+	writeInitReport(initReport{Status: status})
+	return code
+}
@@ -1,12 +1,18 @@
 package core
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"sync"
+	"syscall"
+	"time"
 
 	uuid "github.com/satori/go.uuid"
 )
@@ -21,10 +27,37 @@ const (
 	Running
 	// Complete is the status when a job exits without errors
 	Complete
-	// Error is the status when an error occurs
+	// Error is the status when a job is cancelled or an unexpected error occurs
 	Error
+	// TimedOut is the status when a job is stopped for exceeding its timeout
+	TimedOut
+	// Paused is the status while a running job is frozen
+	Paused
+	// Lost is the status of a job that was running, or queued and could not be requeued, when the server stopped
+	Lost
+	// Failed is the status when a job exits with a non-zero code
+	Failed
+	// StartFailed is the status when the process of a job could not be started
+	StartFailed
+	// Killed is the status when a job is terminated by a signal
+	Killed
 )
 
+// ErrNotRunning is returned when an action requires a running job
+var ErrNotRunning = errors.New("job is not running")
+
+// ErrNotPaused is returned when resuming a job that is not paused
+var ErrNotPaused = errors.New("job is not paused")
+
+// ErrNoStdin is returned when writing to a job that was not given a stdin pipe
+var ErrNoStdin = errors.New("job has no stdin")
+
+// ErrNoTty is returned when resizing the terminal of a job that does not run under a pseudo-terminal
+var ErrNoTty = errors.New("job has no tty")
+
+// DefaultGracePeriod is how long a job has to exit after a stop signal before it is escalated
+const DefaultGracePeriod = time.Second * 10
+
 // String is a convienient way to convert a job status to string
 func (js JobStatus) String() string {
 	switch js {
@@ -34,26 +67,126 @@ func (js JobStatus) String() string {
 		return "complete"
 	case Error:
 		return "error"
+	case TimedOut:
+		return "timed_out"
+	case Paused:
+		return "paused"
+	case Lost:
+		return "lost"
+	case Failed:
+		return "failed"
+	case StartFailed:
+		return "start_failed"
+	case Killed:
+		return "killed"
 	default:
 		return "pending"
 	}
 }
 
+// Finished reports if the status is terminal
+func (js JobStatus) Finished() bool {
+	switch js {
+	case Complete, Error, TimedOut, Lost, Failed, StartFailed, Killed:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseJobStatus converts the string form of a job status back to a JobStatus
+func ParseJobStatus(s string) (JobStatus, error) {
+	for status := Pending; status <= Killed; status++ {
+		if status.String() == s {
+			return status, nil
+		}
+	}
+	return Pending, fmt.Errorf("unknown job status %q", s)
+}
+
+// ResourceUsage is the cpu time and memory used by the process of a job and the descendants it waited for
+type ResourceUsage struct {
+	UserTime   time.Duration `json:"user_time"`
+	SystemTime time.Duration `json:"system_time"`
+	// MaxRSS is the largest resident set size in bytes
+	MaxRSS int64 `json:"max_rss"`
+}
+
 // Job provides a simple interface for job access and management
 type Job struct {
-	ID        string
-	ClientID  string
+	ID       string
+	ClientID string
+	// Command and Args are the command as requested, Cmd is rewritten to run it through the init process
+	Command   string
+	Args      []string
 	Cmd       *exec.Cmd
 	OutputBuf *OutputBuffer
-	status    JobStatus
-	err       error
+	// CreatedAt is when the job was submitted
+	CreatedAt time.Time
+	// Labels are key value pairs set by the client to find the job with
+	Labels map[string]string
+	// Priority orders queued jobs of the same client, higher runs first
+	Priority int
+	// CgroupParent is the cgroup v2 directory the job cgroup is created under, cgroups are disabled when empty
+	CgroupParent string
+	// Limits are applied to the job cgroup
+	Limits *ResourceLimits
+	// Isolation selects the namespaces the job runs in
+	Isolation Isolation
+	// Image is the name of the rootfs image the job runs in, the host filesystem is used when empty
+	Image string
+	// Rootfs is the directory of Image and requires namespace isolation
+	Rootfs string
+	// Workspace is a writable directory mounted at /workspace inside Rootfs
+	Workspace string
+	// Timeout stops the job once it has been running this long, zero disables the timeout
+	Timeout time.Duration
+	// StopSignal is sent when the job times out, defaults to SIGTERM
+	StopSignal os.Signal
+	// GracePeriod is how long the job has to exit after a stop signal before it is escalated
+	GracePeriod time.Duration
+	// OutputLimit caps the output kept for the job, it is unlimited by default
+	OutputLimit OutputLimit
+	// Events receives the lifecycle events of the job when set
+	Events     *EventBus
+	status     JobStatus
+	err        error
+	exitCode   int
+	startedAt  time.Time
+	finishedAt time.Time
+	signal     syscall.Signal
+	// cmdStatus is the wait status of the command reported by the init process
+	cmdStatus    *syscall.WaitStatus
+	usage        *ResourceUsage
+	cgroupStats  *CgroupStats
+	watchers     []func(*Job)
+	cgroup       *Cgroup
+	timer        *pausableTimer
+	timedOut     bool
+	outputKilled bool
+	// stopped is set once the job is asked to stop so it finishes with EventStopped
+	stopped   bool
+	stdin     *os.File
+	stdinRead *os.File
+	tty       bool
+	done      chan struct{}
 	mu        sync.RWMutex
 }
 
 // NewJob creates a new job instance
 func NewJob(clientID string, command string, args ...string) (*Job, error) {
+	return NewJobWithOutputDir(clientID, "", command, args...)
+}
+
+// NewJobWithOutputDir creates a new job instance storing its output in a directory
+// named after the job under outputRoot, a temporary directory is used when it is empty
+func NewJobWithOutputDir(clientID string, outputRoot string, command string, args ...string) (*Job, error) {
 	id := uuid.NewV4().String()
-	outputBuf, err := NewOutputBuffer()
+	dir := ""
+	if outputRoot != "" {
+		dir = filepath.Join(outputRoot, id)
+	}
+	outputBuf, err := NewOutputBuffer(dir)
 	if err != nil {
 		return nil, err
 	}
@@ -61,22 +194,90 @@ func NewJob(clientID string, command string, args ...string) (*Job, error) {
 	return &Job{
 		ID:        id,
 		ClientID:  clientID,
+		Command:   command,
+		Args:      args,
 		Cmd:       exec.Command(command, args...),
+		CreatedAt: time.Now(),
 		status:    Pending,
+		exitCode:  -1,
 		OutputBuf: outputBuf,
+		done:      make(chan struct{}),
 	}, nil
 }
 
-// ExitCode returns a jobs exit code
-func (j *Job) ExitCode() int {
-	if j.Cmd.ProcessState == nil {
-		return -1
+// Done returns a channel closed once the job has finished and all of its output is written
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// FinishedAt returns when the job finished or the zero time if it has not
+func (j *Job) FinishedAt() time.Time {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.finishedAt
+}
+
+// StartedAt returns when the job started running or the zero time if it has not
+func (j *Job) StartedAt() time.Time {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.startedAt
+}
+
+// Duration returns how long the job has been running, or ran for once it has finished
+func (j *Job) Duration() time.Duration {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	if j.startedAt.IsZero() {
+		return 0
+	}
+	if j.finishedAt.IsZero() {
+		return time.Since(j.startedAt)
 	}
-	// This also default to -1 if not exited
-	return j.Cmd.ProcessState.ExitCode()
+	return j.finishedAt.Sub(j.startedAt)
+}
+
+// TermSignal returns the signal that terminated the job, zero if it exited or has not finished
+func (j *Job) TermSignal() syscall.Signal {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.signal
 }
 
-// Error returns an error if an error occurred running the job
+// Usage returns the resources used by the job once it has exited, nil before
+func (j *Job) Usage() *ResourceUsage {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.usage
+}
+
+// CgroupStats returns the counters of the job cgroup, read live while the job is
+// running and as they were when it finished after. Nil for jobs without a cgroup.
+func (j *Job) CgroupStats() *CgroupStats {
+	j.mu.RLock()
+	cgroup, stats := j.cgroup, j.cgroupStats
+	j.mu.RUnlock()
+	if cgroup == nil {
+		return stats
+	}
+	live, err := cgroup.Stats()
+	if err != nil {
+		// The cgroup may have been removed as the job finished
+		j.mu.RLock()
+		defer j.mu.RUnlock()
+		return j.cgroupStats
+	}
+	return &live
+}
+
+// ExitCode returns a jobs exit code, -1 until the job has exited
+func (j *Job) ExitCode() int {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.exitCode
+}
+
+// Error returns why the job did not complete, it is a *JobError once the job has finished
 func (j *Job) Error() error {
 	j.mu.RLock()
 	defer j.mu.RUnlock()
@@ -97,72 +298,607 @@ func (j *Job) Status() JobStatus {
 	return j.status
 }
 
-// UpdateStatus updates a jobs err
+// UpdateStatus updates a jobs status and publishes the matching event
 func (j *Job) UpdateStatus(status JobStatus) {
 	j.mu.Lock()
-	defer j.mu.Unlock()
 	j.status = status
+	if status == Running && j.startedAt.IsZero() {
+		j.startedAt = time.Now()
+	}
+	stopped := j.stopped
+	j.mu.Unlock()
+
+	switch {
+	case status == Running:
+		j.publish(EventStarted)
+	case status.Finished() && stopped:
+		j.publish(EventStopped)
+	case status.Finished():
+		j.publish(EventExited)
+	}
+	j.notify()
 }
 
-// Interrupt sends a SIGINT to the process
-func (j *Job) Interrupt() error {
+// publish sends an event with the current state of the job to its event bus
+func (j *Job) publish(t EventType) {
+	if j.Events == nil {
+		return
+	}
+	j.mu.RLock()
+	e := Event{
+		Type:     t,
+		JobID:    j.ID,
+		ClientID: j.ClientID,
+		Time:     time.Now(),
+		Status:   j.status,
+		ExitCode: j.exitCode,
+	}
+	j.mu.RUnlock()
+	j.Events.Publish(e)
+}
+
+// Watch calls fn after every status change of the job
+func (j *Job) Watch(fn func(*Job)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.watchers = append(j.watchers, fn)
+}
+
+// notify calls the watchers of the job, j.mu must not be held
+func (j *Job) notify() {
+	j.mu.RLock()
+	watchers := j.watchers
+	j.mu.RUnlock()
+	for _, fn := range watchers {
+		fn(j)
+	}
+}
+
+// Signal sends a signal to every process of the job
+func (j *Job) Signal(sig os.Signal) error {
 	if j.Cmd.Process == nil {
-		return fmt.Errorf("unable to interrupt nil process")
+		return fmt.Errorf("unable to signal nil process")
+	}
+	// The process group id may be reused once the job has finished
+	select {
+	case <-j.done:
+		return fmt.Errorf("process already finished")
+	default:
+	}
+
+	j.mu.RLock()
+	cgroup := j.cgroup
+	j.mu.RUnlock()
+	if sig == syscall.SIGKILL {
+		if cgroup != nil {
+			if err := cgroup.Kill(); err != nil {
+				log.Printf("unable to kill cgroup %v", err)
+			}
+		}
+		// The init process can not forward SIGKILL
+		killTree(j.Cmd.Process.Pid)
+		return nil
 	}
-	return j.Cmd.Process.Signal(os.Interrupt)
+
+	// The init process forwards signals to every descendant of the job
+	return j.Cmd.Process.Signal(sig)
+}
+
+// Interrupt sends a SIGINT to the process
+func (j *Job) Interrupt() error {
+	return j.Signal(os.Interrupt)
 }
 
 // Kill sends a SIGKILL to the process.
 func (j *Job) Kill() error {
-	if j.Cmd.Process == nil {
-		return fmt.Errorf("unable to kill nil process")
+	return j.Signal(os.Kill)
+}
+
+// Stop sends sig, defaulting to SIGINT, then escalates through SIGTERM to SIGKILL
+// waiting grace between each signal for the job to exit. A paused job is resumed first
+// so it is able to handle the signal.
+func (j *Job) Stop(sig os.Signal, grace time.Duration) error {
+	if sig == nil {
+		sig = os.Interrupt
+	}
+	if grace == 0 {
+		grace = DefaultGracePeriod
+	}
+	if err := j.Resume(); err != nil && err != ErrNotPaused {
+		return err
+	}
+	j.mu.Lock()
+	j.stopped = true
+	j.mu.Unlock()
+	if err := j.Signal(sig); err != nil {
+		return err
 	}
-	return j.Cmd.Process.Signal(os.Kill)
+	go j.escalate(escalation(sig), grace)
+	return nil
 }
 
-// Start runs a job and handles errors
-func (j *Job) Start() error {
-	err := j.run()
+// Pause freezes every process of a running job, time spent paused does not count towards the timeout
+func (j *Job) Pause() error {
+	if err := j.pause(); err != nil {
+		return err
+	}
+	j.notify()
+	return nil
+}
 
-	if err != nil {
-		log.Print(err)
-		j.UpdateError(err)
-		j.UpdateStatus(Error)
+// pause freezes the job and updates its status without notifying watchers
+func (j *Job) pause() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status != Running {
+		return ErrNotRunning
+	}
+	if err := j.freeze(true); err != nil {
 		return err
 	}
+	if j.timer != nil {
+		j.timer.Pause()
+	}
+	j.status = Paused
+	return nil
+}
 
-	j.UpdateStatus(Complete)
+// Resume thaws a paused job
+func (j *Job) Resume() error {
+	if err := j.resume(); err != nil {
+		return err
+	}
+	j.notify()
 	return nil
 }
 
-// Run executes the job and updates its state
-func (j *Job) run() error {
-	cmd := j.Cmd
+// resume thaws the job and updates its status without notifying watchers
+func (j *Job) resume() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.status != Paused {
+		return ErrNotPaused
+	}
+	if err := j.freeze(false); err != nil {
+		return err
+	}
+	if j.timer != nil {
+		j.timer.Resume()
+	}
+	j.status = Running
+	return nil
+}
 
-	stdout, err := cmd.StdoutPipe()
+// freeze uses the cgroup freezer when available and otherwise SIGSTOP or SIGCONT, j.mu must be held
+func (j *Job) freeze(frozen bool) error {
+	if j.cgroup != nil {
+		err := j.cgroup.Freeze(frozen)
+		if err == nil {
+			return nil
+		}
+		log.Printf("unable to use cgroup freezer %v", err)
+	}
+
+	sig := syscall.SIGCONT
+	if frozen {
+		sig = syscall.SIGSTOP
+	}
+	// SIGSTOP can not be forwarded by the init process so signal its descendants directly
+	for _, child := range descendants(j.Cmd.Process.Pid) {
+		syscall.Kill(child, sig)
+	}
+	return nil
+}
+
+// escalation returns the signals sent after sig if a job does not exit
+func escalation(sig os.Signal) []os.Signal {
+	switch sig {
+	case syscall.SIGKILL:
+		return nil
+	case syscall.SIGTERM:
+		return []os.Signal{syscall.SIGKILL}
+	default:
+		return []os.Signal{syscall.SIGTERM, syscall.SIGKILL}
+	}
+}
+
+// escalate sends each signal in turn until the job exits
+func (j *Job) escalate(sigs []os.Signal, grace time.Duration) {
+	for _, sig := range sigs {
+		select {
+		case <-j.done:
+			return
+		case <-time.After(grace):
+		}
+		if err := j.Signal(sig); err != nil {
+			log.Printf("unable to escalate to %v %v", sig, err)
+			return
+		}
+	}
+}
+
+// timeout stops a job that has exceeded its timeout
+func (j *Job) timeout() {
+	j.mu.Lock()
+	j.timedOut = true
+	j.mu.Unlock()
+
+	sig := j.StopSignal
+	if sig == nil {
+		sig = syscall.SIGTERM
+	}
+	if err := j.Stop(sig, j.GracePeriod); err != nil {
+		log.Printf("unable to stop timed out job %v", err)
+	}
+}
+
+// outputTruncated publishes that output was dropped and kills the job if its output limit policy is kill
+func (j *Job) outputTruncated() {
+	j.publish(EventOutputTruncated)
+	if j.OutputLimit.Policy != OutputKill {
+		return
+	}
+
+	j.mu.Lock()
+	j.outputKilled = true
+	j.mu.Unlock()
+	if err := j.Kill(); err != nil {
+		log.Printf("unable to kill job exceeding its output limit %v", err)
+	}
+}
+
+// OpenStdin gives the job a stdin pipe written to with WriteStdin, it must be called before the job starts.
+// Jobs without a stdin pipe read from /dev/null.
+func (j *Job) OpenStdin() error {
+	r, w, err := os.Pipe()
 	if err != nil {
 		return err
 	}
-	stderr, err := cmd.StderrPipe()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.stdin = w
+	j.stdinRead = r
+	j.Cmd.Stdin = r
+	return nil
+}
+
+// OpenTty runs the job under a pseudo-terminal, it must be called before the job starts.
+// Output is read from the terminal and WriteStdin writes to it as if typed.
+func (j *Job) OpenTty() error {
+	master, slave, err := openPty()
 	if err != nil {
 		return err
 	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.stdin = master
+	j.stdinRead = slave
+	j.tty = true
+	j.Cmd.Stdin = slave
+	j.Cmd.Stdout = slave
+	j.Cmd.Stderr = slave
+	return nil
+}
+
+// Tty reports if the job runs under a pseudo-terminal
+func (j *Job) Tty() bool {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.tty
+}
 
-	output := io.MultiReader(stdout, stderr)
-	err = cmd.Start()
+// Resize sets the terminal size of a job running under a pseudo-terminal
+func (j *Job) Resize(size Winsize) error {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	if !j.tty {
+		return ErrNoTty
+	}
+	return setWinsize(j.stdin, size)
+}
+
+// WriteStdin writes to the stdin pipe of the job, it blocks while the pipe is full
+func (j *Job) WriteStdin(b []byte) (int, error) {
+	j.mu.RLock()
+	stdin := j.stdin
+	j.mu.RUnlock()
+	if stdin == nil {
+		return 0, ErrNoStdin
+	}
+	return stdin.Write(b)
+}
+
+// CloseStdin closes the stdin pipe of the job so it reads EOF
+func (j *Job) CloseStdin() error {
+	j.mu.RLock()
+	stdin := j.stdin
+	j.mu.RUnlock()
+	if stdin == nil {
+		return ErrNoStdin
+	}
+	// The terminal is also the output of the job so send end of file instead of closing it
+	if j.Tty() {
+		_, err := stdin.Write([]byte{4})
+		return err
+	}
+	if err := stdin.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
+		return err
+	}
+	return nil
+}
+
+// closePipes releases both ends of the stdin pipe once the job is done
+func (j *Job) closePipes() {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	if j.stdin != nil {
+		j.stdin.Close()
+		j.stdinRead.Close()
+	}
+}
+
+// Discard releases the stdin pipe or terminal and the output of a job that is not submitted
+func (j *Job) Discard() error {
+	j.closePipes()
+	return j.OutputBuf.Remove()
+}
+
+// cancel finishes a job that was never started
+func (j *Job) cancel(err error) {
+	j.closePipes()
+	j.mu.Lock()
+	j.finishedAt = time.Now()
+	j.stopped = true
+	j.mu.Unlock()
+	j.UpdateError(&JobError{Code: ErrorCodeCancelled, Message: err.Error(), Err: err})
+	j.UpdateStatus(Error)
+	close(j.done)
+}
+
+// Start runs a job and handles errors
+func (j *Job) Start() error {
+	defer close(j.done)
+	defer j.closePipes()
+	err := j.run()
+
+	j.mu.Lock()
+	j.finishedAt = time.Now()
+	if state := j.Cmd.ProcessState; state != nil {
+		// The status of the command is used unless the init process was killed first
+		ws, _ := state.Sys().(syscall.WaitStatus)
+		if j.cmdStatus != nil {
+			ws = *j.cmdStatus
+		}
+		j.exitCode = ws.ExitStatus()
+		if ws.Signaled() {
+			j.signal = ws.Signal()
+		}
+		if ru, ok := state.SysUsage().(*syscall.Rusage); ok {
+			j.usage = &ResourceUsage{
+				UserTime:   time.Duration(ru.Utime.Nano()),
+				SystemTime: time.Duration(ru.Stime.Nano()),
+				// ru_maxrss is in kilobytes on linux
+				MaxRSS: ru.Maxrss * 1024,
+			}
+		}
+	}
+	j.mu.Unlock()
+
+	status, jobErr := j.result(err)
+	if jobErr == nil {
+		j.UpdateStatus(status)
+		return nil
+	}
+	if jobErr.Code == ErrorCodeStartFailed || jobErr.Code == ErrorCodeInternal {
+		log.Print(err)
+	}
+	j.UpdateError(jobErr)
+	j.UpdateStatus(status)
+	return jobErr
+}
+
+// result returns the final status of a job and why it did not complete given the error returned by run
+func (j *Job) result(err error) (JobStatus, *JobError) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	var sf *startFailure
+	switch {
+	case errors.As(err, &sf):
+		return StartFailed, startError(j.Command, sf.err)
+	case err != nil && j.startedAt.IsZero():
+		return Error, &JobError{Code: ErrorCodeInternal, Message: "job failed with an internal error", Err: err}
+	case j.timedOut:
+		msg := fmt.Sprintf("job timed out after %v", j.Timeout)
+		return TimedOut, &JobError{Code: ErrorCodeTimeout, Message: msg, Err: err}
+	case j.outputKilled:
+		msg := fmt.Sprintf("job output exceeded %v bytes", j.OutputLimit.MaxBytes)
+		return Killed, &JobError{Code: ErrorCodeOutputLimit, Message: msg, Err: err}
+	case j.signal != 0:
+		msg := fmt.Sprintf("job was killed by %v", SignalName(j.signal))
+		return Killed, &JobError{Code: ErrorCodeSignaled, Message: msg, Err: err}
+	case j.exitCode > 0:
+		msg := fmt.Sprintf("job exited with code %v", j.exitCode)
+		return Failed, &JobError{Code: ErrorCodeExitStatus, Message: msg, Err: err}
+	case err != nil:
+		return Error, &JobError{Code: ErrorCodeInternal, Message: "job failed with an internal error", Err: err}
+	default:
+		return Complete, nil
+	}
+}
+
+// Run executes the job and updates its state
+func (j *Job) run() error {
+	if j.Rootfs != "" && j.Isolation != IsolationNamespaces {
+		return fmt.Errorf("rootfs requires namespace isolation")
+	}
+	if j.Workspace != "" {
+		if err := os.MkdirAll(j.Workspace, 0755); err != nil {
+			return err
+		}
+		// The workspace must be writable by the user the job runs as
+		if attr := j.Cmd.SysProcAttr; attr != nil && attr.Credential != nil {
+			err := os.Chown(j.Workspace, int(attr.Credential.Uid), int(attr.Credential.Gid))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	// Every job runs through the init process which reaps all of its descendants,
+	// including those that leave its process group or session
+	cmd, initReport, initResume, err := initCommand(j.Cmd, initConfig{
+		Namespaces: j.Isolation == IsolationNamespaces,
+		Hostname:   j.ID,
+		Rootfs:     j.Rootfs,
+		Workspace:  j.Workspace,
+		Tty:        j.tty,
+	})
 	if err != nil {
 		return err
 	}
+	j.Cmd = cmd
+	defer initReport.Close()
+	defer initResume.Close()
+	defer cmd.ExtraFiles[0].Close()
+	defer cmd.ExtraFiles[1].Close()
+
+	outputs := make(map[Stream]io.Reader)
+	if j.tty {
+		outputs[Stdout] = ptyReader{master: j.stdin}
+	} else {
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return err
+		}
+		stderr, err := cmd.StderrPipe()
+		if err != nil {
+			return err
+		}
+		outputs[Stdout] = stdout
+		outputs[Stderr] = stderr
+	}
+
+	var cgroup *Cgroup
+	if j.CgroupParent != "" {
+		cgroup, err = NewCgroup(j.CgroupParent, j.ID, j.Limits)
+		if err != nil {
+			return err
+		}
+		defer j.removeCgroup(cgroup)
+	}
+
+	// The init process gives the terminal to the session of the command
+	if j.tty {
+		cmd.SysProcAttr.Setsid = true
+	} else {
+		cmd.SysProcAttr.Setpgid = true
+	}
+	j.OutputBuf.SetLimit(j.OutputLimit, j.outputTruncated)
+
+	if err = cmd.Start(); err != nil {
+		return fmt.Errorf("unable to start init process %v", err)
+	}
+	// Only the job holds the read end of stdin so writes fail once it exits
+	if j.stdinRead != nil {
+		j.stdinRead.Close()
+	}
+	// Likewise only the init process holds its ends of the report and resume pipes
+	cmd.ExtraFiles[0].Close()
+	cmd.ExtraFiles[1].Close()
+
+	// The init process waits to be resumed so the command never runs outside the cgroup
+	if cgroup != nil {
+		if err = cgroup.AddProcess(cmd.Process.Pid); err != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+			return err
+		}
+		j.mu.Lock()
+		j.cgroup = cgroup
+		j.mu.Unlock()
+	}
+	if err = resumeInit(initResume); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+	report := json.NewDecoder(initReport)
+	if err = waitInitStart(report); err != nil {
+		cmd.Wait()
+		// Failures of the init process itself are internal
+		var ie *initError
+		if errors.As(err, &ie) && ie.Op != initOpSetup {
+			return &startFailure{err}
+		}
+		return err
+	}
 	j.UpdateStatus(Running)
 
-	w, err := j.OutputBuf.NewWriter()
+	if j.Timeout > 0 {
+		timer := newPausableTimer(j.Timeout, j.timeout)
+		j.mu.Lock()
+		j.timer = timer
+		j.mu.Unlock()
+		defer timer.Stop()
+	}
+
+	// Streams are read concurrently so a full pipe never blocks the job
+	var wg sync.WaitGroup
+	for stream, r := range outputs {
+		wg.Add(1)
+		go func(stream Stream, r io.Reader) {
+			defer wg.Done()
+			j.copyOutput(stream, r)
+		}(stream, r)
+	}
+	wg.Wait()
+
+	// The init process only exits once every descendant has exited
+	err = cmd.Wait()
+	if ws, ok := waitInitStatus(report); ok {
+		j.mu.Lock()
+		j.cmdStatus = &ws
+		j.mu.Unlock()
+	}
+	return err
+}
+
+// copyOutput copies r into the output buffer as chunks of stream
+func (j *Job) copyOutput(stream Stream, r io.Reader) {
+	w, err := j.OutputBuf.NewWriter(stream)
 	if err != nil {
 		log.Printf("unable to open log writer %v", err)
+		// Keep draining so the job does not block writing
+		io.Copy(ioutil.Discard, r)
+		return
 	}
-	_, err = io.Copy(w, output)
+	defer w.Close()
+	if _, err = io.Copy(w, r); err != nil {
+		log.Printf("unable to copy %v to log %v", stream, err)
+	}
+}
+
+// removeCgroup kills anything that escaped the job process group and removes the cgroup
+func (j *Job) removeCgroup(cgroup *Cgroup) {
+	stats, err := cgroup.Stats()
 	if err != nil {
-		log.Printf("unable to copy output to log %v", err)
+		log.Printf("unable to read cgroup stats %v", err)
 	}
+	j.mu.Lock()
+	j.cgroup = nil
+	if err == nil {
+		j.cgroupStats = &stats
+	}
+	j.mu.Unlock()
 
-	return cmd.Wait()
+	for i := 0; i < 100; i++ {
+		pids, err := cgroup.Processes()
+		if err != nil || len(pids) == 0 {
+			break
+		}
+		cgroup.Kill()
+		time.Sleep(time.Millisecond * 10)
+	}
+	if err := cgroup.Remove(); err != nil {
+		log.Printf("unable to remove cgroup %v", err)
+	}
 }
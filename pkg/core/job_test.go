@@ -1,16 +1,29 @@
 package core_test
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
+	"os/signal"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"syscall"
 	"testing"
 	"time"
+	"unsafe"
 
 	"github.com/dboslee/job-worker/pkg/core"
 )
 
+func TestMain(m *testing.M) {
+	core.RunInit()
+	os.Exit(m.Run())
+}
+
 func TestNewJobID(t *testing.T) {
 	job, _ := core.NewJob("test-client", "")
 
@@ -28,6 +41,87 @@ func TestError(t *testing.T) {
 	}
 }
 
+func TestStartFailed(t *testing.T) {
+	// The command is looked up by the init process inside a rootfs
+	rootfs := t.TempDir()
+	for _, dir := range []string{"proc", "tmp", "dev"} {
+		os.Mkdir(filepath.Join(rootfs, dir), 0755)
+	}
+	// A script naming a missing interpreter exists but can not be executed
+	script := filepath.Join(t.TempDir(), "script")
+	ioutil.WriteFile(script, []byte("#!/job-worker-missing\n"), 0755)
+	missingDir := filepath.Join(t.TempDir(), "missing")
+
+	cases := []struct {
+		isolation core.Isolation
+		rootfs    string
+		command   string
+		dir       string
+		message   string
+	}{
+		{core.IsolationNone, "", "job-worker-missing", "", "command not found: job-worker-missing"},
+		{core.IsolationNamespaces, "", "job-worker-missing", "", "command not found: job-worker-missing"},
+		{core.IsolationNamespaces, rootfs, "job-worker-missing", "", "command not found: job-worker-missing"},
+		{core.IsolationNone, "", "/job-worker-missing", "", "command not found: /job-worker-missing"},
+		{core.IsolationNone, "", "true", missingDir, "working directory not found: " + missingDir},
+		{core.IsolationNone, "", "true", script, "working directory is not a directory: " + script},
+		{core.IsolationNone, "", script, "", "interpreter not found: " + script},
+	}
+	for _, tc := range cases {
+		if tc.isolation == core.IsolationNamespaces && os.Geteuid() != 0 {
+			continue
+		}
+		job, _ := core.NewJob("test-client", tc.command)
+		job.Isolation = tc.isolation
+		job.Rootfs = tc.rootfs
+		job.Cmd.Dir = tc.dir
+		job.Start()
+
+		var jobErr *core.JobError
+		if !errors.As(job.Error(), &jobErr) || jobErr.Code != core.ErrorCodeStartFailed {
+			t.Errorf("%v: expected a start failure got: %v", tc.isolation, job.Error())
+			continue
+		}
+		if jobErr.Message != tc.message {
+			t.Errorf("%v: message want: %q got: %q", tc.isolation, tc.message, jobErr.Message)
+		}
+		if status := job.Status(); status != core.StartFailed {
+			t.Errorf("%v: unexpected status got: %v want: %v", tc.isolation, status, core.StartFailed)
+		}
+	}
+}
+
+func TestInternalError(t *testing.T) {
+	// Errors before the process is started are not start failures
+	job, _ := core.NewJob("test-client", "exit", "0")
+	job.Cmd = mockExec("exit", "0")
+	job.Rootfs = t.TempDir()
+	job.Start()
+
+	var jobErr *core.JobError
+	if !errors.As(job.Error(), &jobErr) || jobErr.Code != core.ErrorCodeInternal {
+		t.Errorf("expected an internal error got: %v", job.Error())
+	}
+	if status := job.Status(); status != core.Error {
+		t.Errorf("unexpected status got: %v want: %v", status, core.Error)
+	}
+
+	if os.Geteuid() != 0 {
+		return
+	}
+	// Neither are failures of the init process to set up an empty rootfs
+	job, _ = core.NewJob("test-client", "job-worker-missing")
+	job.Isolation = core.IsolationNamespaces
+	job.Rootfs = t.TempDir()
+	job.Start()
+	if !errors.As(job.Error(), &jobErr) || jobErr.Code != core.ErrorCodeInternal {
+		t.Errorf("expected an internal error got: %v", job.Error())
+	}
+	if status := job.Status(); status != core.Error {
+		t.Errorf("unexpected status got: %v want: %v", status, core.Error)
+	}
+}
+
 func TestStatus(t *testing.T) {
 	job, _ := core.NewJob("test-client", "exit", "0")
 	job.Cmd = mockExec("exit", "0")
@@ -49,6 +143,8 @@ func TestExitCode(t *testing.T) {
 		{"exit", []string{"0"}, 0},
 		{"exit", []string{"1"}, 1},
 		{"exit", []string{"2"}, 2},
+		// Codes above 128 are exit codes like any other, not signals
+		{"exit", []string{"130"}, 130},
 	}
 
 	for _, tc := range cases {
@@ -58,6 +154,35 @@ func TestExitCode(t *testing.T) {
 		if code := job.ExitCode(); code != tc.code {
 			t.Errorf("exit code want: %v got: %v", tc.code, code)
 		}
+		if tc.code != 0 && job.Status() != core.Failed {
+			t.Errorf("unexpected status got: %v want: %v", job.Status(), core.Failed)
+		}
+		if sig := job.TermSignal(); sig != 0 {
+			t.Errorf("unexpected signal %v", sig)
+		}
+	}
+}
+
+func TestKilledExitCode(t *testing.T) {
+	job, _ := core.NewJob("test-client", "sleep", "5")
+	job.Cmd = mockExec("sleep", "5")
+	done := make(chan error)
+	go func() {
+		done <- job.Start()
+	}()
+	waitStatus(t, job, core.Running)
+	job.Stop(syscall.SIGTERM, time.Second)
+	<-done
+
+	// A job killed by a signal has no exit code
+	if code := job.ExitCode(); code != -1 {
+		t.Errorf("exit code want: -1 got: %v", code)
+	}
+	if sig := job.TermSignal(); sig != syscall.SIGTERM {
+		t.Errorf("signal want: %v got: %v", syscall.SIGTERM, sig)
+	}
+	if status := job.Status(); status != core.Killed {
+		t.Errorf("unexpected status got: %v want: %v", status, core.Killed)
 	}
 }
 
@@ -84,6 +209,38 @@ func TestOutput(t *testing.T) {
 	}
 }
 
+func TestOutputStderr(t *testing.T) {
+	job, _ := core.NewJob("test-client", "stderr")
+	job.Cmd = mockExec("stderr")
+	done := make(chan error)
+	go func() {
+		done <- job.Start()
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		job.Kill()
+		t.Fatal("job blocked writing stderr")
+	}
+
+	r, _ := job.OutputBuf.NewReader()
+	defer r.Close()
+	sizes := make(map[core.Stream]int)
+	for {
+		chunk, err := r.Next()
+		if err != nil {
+			break
+		}
+		sizes[chunk.Stream] += len(chunk.Data)
+	}
+	if sizes[core.Stderr] != 1<<20 || sizes[core.Stdout] != len("done\n") {
+		t.Errorf("unexpected output sizes %v", sizes)
+	}
+}
+
 func TestInterrupt(t *testing.T) {
 	job, _ := core.NewJob("test-client", "sleep", "5")
 	job.Cmd = mockExec("sleep", "5")
@@ -99,8 +256,37 @@ func TestInterrupt(t *testing.T) {
 	}
 
 	<-done
-	if status := job.Status(); status != core.Error {
-		t.Errorf("unexpected status got: %d, want: %d", status, core.Error)
+	if status := job.Status(); status != core.Killed {
+		t.Errorf("unexpected status got: %v, want: %v", status, core.Killed)
+	}
+	if sig := job.TermSignal(); sig != syscall.SIGINT {
+		t.Errorf("unexpected signal got: %v, want: %v", sig, syscall.SIGINT)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	job, _ := core.NewJob("test-client", "exit", "0")
+	job.Cmd = mockExec("exit", "0")
+	if job.Usage() != nil || !job.StartedAt().IsZero() || job.Duration() != 0 {
+		t.Errorf("expected no usage or start time before the job starts")
+	}
+	job.Start()
+
+	if job.StartedAt().IsZero() || job.StartedAt().After(job.FinishedAt()) {
+		t.Errorf("expected start time before finish got: %v %v", job.StartedAt(), job.FinishedAt())
+	}
+	if job.Duration() != job.FinishedAt().Sub(job.StartedAt()) {
+		t.Errorf("expected duration from start to finish got: %v", job.Duration())
+	}
+	usage := job.Usage()
+	if usage == nil || usage.MaxRSS <= 0 || usage.UserTime+usage.SystemTime <= 0 {
+		t.Errorf("expected resource usage got: %+v", usage)
+	}
+	if sig := job.TermSignal(); sig != 0 {
+		t.Errorf("expected no signal got: %v", sig)
+	}
+	if job.CgroupStats() != nil {
+		t.Errorf("expected no cgroup stats without a cgroup")
 	}
 }
 
@@ -115,6 +301,397 @@ func TestInterruptAfterExit(t *testing.T) {
 	}
 }
 
+func TestTimeout(t *testing.T) {
+	cases := []struct {
+		command string
+	}{
+		{"sleep"},
+		// Ignores the stop signal so must be killed after the grace period
+		{"ignore"},
+	}
+
+	for _, tc := range cases {
+		job, _ := core.NewJob("test-client", tc.command, "5")
+		job.Cmd = mockExec(tc.command, "5")
+		job.Timeout = time.Millisecond * 100
+		job.StopSignal = syscall.SIGTERM
+		job.GracePeriod = time.Millisecond * 100
+
+		start := time.Now()
+		if err := job.Start(); err == nil {
+			t.Errorf("expected timeout error")
+		}
+		if status := job.Status(); status != core.TimedOut {
+			t.Errorf("unexpected status got: %v want: %v", status, core.TimedOut)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second*2 {
+			t.Errorf("job took %v to time out", elapsed)
+		}
+	}
+}
+
+func TestOutputLimitKill(t *testing.T) {
+	job, _ := core.NewJob("test-client", "flood")
+	job.Cmd = mockExec("flood")
+	job.OutputLimit = core.OutputLimit{MaxBytes: 1000, Policy: core.OutputKill}
+
+	done := make(chan error)
+	go func() { done <- job.Start() }()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("expected output limit error")
+		}
+	case <-time.After(time.Second * 5):
+		job.Kill()
+		t.Fatal("job was not killed after exceeding its output limit")
+	}
+	if status := job.Status(); status != core.Killed {
+		t.Errorf("unexpected status got: %v want: %v", status, core.Killed)
+	}
+	r, _ := job.OutputBuf.NewReader()
+	defer r.Close()
+	if b, _ := ioutil.ReadAll(r); len(b) != 1000 {
+		t.Errorf("expected 1000 bytes of output got: %v", len(b))
+	}
+	if dropped := job.OutputBuf.Truncated(); dropped == 0 {
+		t.Errorf("expected output to be truncated")
+	}
+}
+
+func TestStopEscalation(t *testing.T) {
+	job, _ := core.NewJob("test-client", "ignore", "5")
+	job.Cmd = mockExec("ignore", "5")
+	done := make(chan error)
+	go func() {
+		done <- job.Start()
+	}()
+	waitOutput(t, job, "ready\n")
+
+	// SIGINT and SIGTERM are ignored so the job is only stopped by SIGKILL
+	if err := job.Stop(syscall.SIGINT, time.Millisecond*50); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 2):
+		t.Fatalf("job was not killed")
+	}
+	if sig := job.TermSignal(); sig != syscall.SIGKILL {
+		t.Errorf("unexpected signal got: %v want: %v", sig, syscall.SIGKILL)
+	}
+}
+
+func TestSignal(t *testing.T) {
+	job, _ := core.NewJob("test-client", "hup")
+	job.Cmd = mockExec("hup")
+	done := make(chan error)
+	go func() {
+		done <- job.Start()
+	}()
+	waitOutput(t, job, "ready\n")
+
+	if err := job.Signal(syscall.SIGHUP); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+	waitOutput(t, job, "ready\nhup\n")
+}
+
+func TestStopProcessTree(t *testing.T) {
+	job, _ := core.NewJob("test-client", "spawn", "5")
+	job.Cmd = mockExec("spawn", "5")
+	done := make(chan error)
+	go func() {
+		done <- job.Start()
+	}()
+	pid := waitPid(t, job)
+
+	if err := job.Stop(syscall.SIGTERM, time.Second); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	<-done
+	if err := syscall.Kill(pid, 0); err != syscall.ESRCH {
+		t.Errorf("expected grandchild %v to be gone got: %v", pid, err)
+	}
+}
+
+func TestWaitProcessTree(t *testing.T) {
+	// The child exits straight away leaving an orphaned grandchild which may
+	// have left the process group of the job
+	for _, command := range []string{"orphan", "detach"} {
+		job, _ := core.NewJob("test-client", command, "1")
+		job.Cmd = mockExec(command, "1")
+		start := time.Now()
+		if err := job.Start(); err != nil {
+			t.Fatalf("%v: unexpected error %v", command, err)
+		}
+		if elapsed := time.Since(start); elapsed < time.Second {
+			t.Errorf("%v: job finished after %v before its grandchild", command, elapsed)
+		}
+
+		pid := waitPid(t, job)
+		if err := syscall.Kill(pid, 0); err != syscall.ESRCH {
+			t.Errorf("%v: expected grandchild %v to be gone got: %v", command, pid, err)
+		}
+	}
+}
+
+func TestStopDetachedProcess(t *testing.T) {
+	job, _ := core.NewJob("test-client", "detach", "5")
+	job.Cmd = mockExec("detach", "5")
+	done := make(chan error)
+	go func() {
+		done <- job.Start()
+	}()
+	pid := waitPid(t, job)
+
+	if err := job.Stop(syscall.SIGTERM, time.Second); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	select {
+	case <-done:
+	case <-time.After(time.Second * 3):
+		t.Fatalf("job was not stopped")
+	}
+	if err := syscall.Kill(pid, 0); err != syscall.ESRCH {
+		t.Errorf("expected grandchild %v to be gone got: %v", pid, err)
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	job, _ := core.NewJob("test-client", "sleep", "5")
+	job.Cmd = mockExec("sleep", "5")
+	go job.Start()
+	waitStatus(t, job, core.Running)
+
+	if err := job.Resume(); err != core.ErrNotPaused {
+		t.Errorf("expected not paused error got: %v", err)
+	}
+	if err := job.Pause(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if status := job.Status(); status != core.Paused {
+		t.Errorf("unexpected status got: %v want: %v", status, core.Paused)
+	}
+	// The command runs as a child of the init process
+	pid := childPid(t, job.Cmd.Process.Pid)
+	waitState(t, pid, "T")
+
+	if err := job.Resume(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if state := processState(pid); state == "T" {
+		t.Errorf("process still stopped after resume")
+	}
+	job.Kill()
+}
+
+func TestPauseTimeout(t *testing.T) {
+	job, _ := core.NewJob("test-client", "sleep", "5")
+	job.Cmd = mockExec("sleep", "5")
+	job.Timeout = time.Millisecond * 300
+	job.GracePeriod = time.Millisecond * 100
+	done := make(chan error)
+	start := time.Now()
+	go func() {
+		done <- job.Start()
+	}()
+	waitStatus(t, job, core.Running)
+
+	job.Pause()
+	time.Sleep(time.Millisecond * 500)
+	job.Resume()
+	<-done
+
+	if status := job.Status(); status != core.TimedOut {
+		t.Errorf("unexpected status got: %v want: %v", status, core.TimedOut)
+	}
+	if elapsed := time.Since(start); elapsed < time.Millisecond*800 {
+		t.Errorf("paused time counted towards timeout, timed out after %v", elapsed)
+	}
+}
+
+// processState returns the state field of /proc/<pid>/stat
+func processState(pid int) string {
+	b, _ := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	fields := strings.Fields(string(b[strings.LastIndexByte(string(b), ')')+1:]))
+	return fields[0]
+}
+
+// waitState waits for a process to reach a state as stop signals are delivered asynchronously
+func waitState(t *testing.T, pid int, want string) {
+	deadline := time.Now().Add(time.Second)
+	for {
+		state := processState(pid)
+		if state == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("process state want: %v got: %v", want, state)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// childPid returns the first child of a process, children are listed per thread
+func childPid(t *testing.T, pid int) int {
+	tasks, _ := filepath.Glob(fmt.Sprintf("/proc/%d/task/*/children", pid))
+	for _, task := range tasks {
+		b, _ := ioutil.ReadFile(task)
+		if fields := strings.Fields(string(b)); len(fields) > 0 {
+			child, _ := strconv.Atoi(fields[0])
+			return child
+		}
+	}
+	t.Fatalf("process %v has no children", pid)
+	return 0
+}
+
+// waitPid waits for a job to output the pid of its grandchild
+func waitPid(t *testing.T, job *core.Job) int {
+	deadline := time.Now().Add(time.Second * 5)
+	for {
+		r, _ := job.OutputBuf.NewReader()
+		b, _ := ioutil.ReadAll(r)
+		r.Close()
+		if pid, err := strconv.Atoi(strings.TrimSpace(string(b))); err == nil {
+			return pid
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no pid in output %q", b)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+// waitOutput waits for the output of a job to equal want or fails the test
+func waitOutput(t *testing.T, job *core.Job, want string) {
+	deadline := time.Now().Add(time.Second * 5)
+	for {
+		r, _ := job.OutputBuf.NewReader()
+		b, _ := ioutil.ReadAll(r)
+		r.Close()
+		if string(b) == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("output want: %q got: %q", want, b)
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func TestStdin(t *testing.T) {
+	job, _ := core.NewJob("test-client", "cat")
+	job.Cmd = mockExec("cat")
+	if _, err := job.WriteStdin([]byte("x")); err != core.ErrNoStdin {
+		t.Errorf("expected no stdin error got: %v", err)
+	}
+	if err := job.OpenStdin(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	done := make(chan error)
+	go func() {
+		done <- job.Start()
+	}()
+
+	for _, s := range []string{"hello ", "world"} {
+		if _, err := job.WriteStdin([]byte(s)); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if err := job.CloseStdin(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// The job only exits once it reads EOF
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		job.Kill()
+		t.Fatal("job did not exit after stdin was closed")
+	}
+	waitOutput(t, job, "hello world")
+}
+
+func TestDiscard(t *testing.T) {
+	job, _ := core.NewJob("test-client", "cat")
+	if err := job.OpenStdin(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// A job that is never started releases its pipe and output
+	if err := job.Discard(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, err := job.WriteStdin([]byte("x")); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("expected closed stdin got: %v", err)
+	}
+	if _, err := os.Stat(job.OutputBuf.Dir()); !os.IsNotExist(err) {
+		t.Errorf("expected output to be removed got: %v", err)
+	}
+}
+
+func TestTty(t *testing.T) {
+	isolation := []core.Isolation{core.IsolationNone}
+	if os.Geteuid() == 0 {
+		isolation = append(isolation, core.IsolationNamespaces)
+	}
+	for _, iso := range isolation {
+		job, _ := core.NewJob("test-client", "tty")
+		job.Cmd = mockExec("tty")
+		job.Isolation = iso
+		if err := job.Resize(core.Winsize{Rows: 24, Cols: 80}); err != core.ErrNoTty {
+			t.Errorf("expected no tty error got: %v", err)
+		}
+		if err := job.OpenTty(); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if err := job.Resize(core.Winsize{Rows: 24, Cols: 80}); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if err := job.Start(); err != nil {
+			t.Fatalf("%v: unexpected error %v", iso, err)
+		}
+		// The terminal translates newlines
+		waitOutput(t, job, "24 80 true\r\n")
+	}
+}
+
+func TestIsolation(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("namespaces require root")
+	}
+	job, _ := core.NewJob("test-client", "ppid")
+	job.Cmd = mockExec("ppid")
+	job.Isolation = core.IsolationNamespaces
+	if err := job.Start(); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	r, _ := job.OutputBuf.NewReader()
+	b := make([]byte, 128)
+	n, _ := r.Read(b)
+	want := fmt.Sprintf("1 %v\n", job.ID)
+	if output := string(b[:n]); output != want {
+		t.Errorf("output want: %q got: %q", want, output)
+	}
+}
+
+func TestCheckNamespaces(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("namespaces require root")
+	}
+	if err := core.CheckNamespaces(); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
 func TestHelperProcess(t *testing.T) {
 	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
 		return
@@ -144,6 +721,61 @@ func TestHelperProcess(t *testing.T) {
 	case "sleep":
 		n, _ := strconv.Atoi(args[0])
 		time.Sleep(time.Second * time.Duration(n))
+	case "ignore":
+		signal.Ignore(syscall.SIGINT, syscall.SIGTERM)
+		fmt.Println("ready")
+		n, _ := strconv.Atoi(args[0])
+		time.Sleep(time.Second * time.Duration(n))
+	case "hup":
+		c := make(chan os.Signal, 1)
+		signal.Notify(c, syscall.SIGHUP)
+		fmt.Println("ready")
+		<-c
+		fmt.Println("hup")
+	case "spawn", "orphan", "detach":
+		// Start a grandchild without our stdout so only the pid is output
+		child := mockExec("sleep", args[0])
+		if cmd == "detach" {
+			// Leave the process group of the job as a daemon would
+			child.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+		}
+		if err := child.Start(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(child.Process.Pid)
+		if cmd == "spawn" {
+			n, _ := strconv.Atoi(args[0])
+			time.Sleep(time.Second * time.Duration(n))
+		}
+	case "stderr":
+		// More than a pipe buffer of stderr before any stdout
+		os.Stderr.Write(make([]byte, 1<<20))
+		fmt.Println("done")
+	case "cat":
+		io.Copy(os.Stdout, os.Stdin)
+	case "flood":
+		// Write output until killed
+		line := []byte(strings.Repeat("y", 99) + "\n")
+		for {
+			if _, err := os.Stdout.Write(line); err != nil {
+				os.Exit(1)
+			}
+		}
+	case "tty":
+		// Report the terminal size and if the terminal controls our session
+		var size core.Winsize
+		_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, 0, syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&size)))
+		if errno != 0 {
+			fmt.Println(errno)
+			os.Exit(1)
+		}
+		var sid int
+		syscall.Syscall(syscall.SYS_IOCTL, 0, syscall.TIOCGSID, uintptr(unsafe.Pointer(&sid)))
+		fmt.Println(size.Rows, size.Cols, sid == os.Getpid())
+	case "ppid":
+		hostname, _ := os.Hostname()
+		fmt.Println(os.Getppid(), hostname)
 	default:
 		fmt.Fprintf(os.Stderr, "No such command %q\n", cmd)
 		os.Exit(2)
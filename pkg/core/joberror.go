@@ -0,0 +1,134 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// ErrorCode classifies why a job finished without completing
+type ErrorCode int
+
+const (
+	// ErrorCodeInternal is an unexpected error running the job
+	ErrorCodeInternal ErrorCode = iota
+	// ErrorCodeStartFailed is a job whose process could not be started
+	ErrorCodeStartFailed
+	// ErrorCodeExitStatus is a job that exited with a non-zero code
+	ErrorCodeExitStatus
+	// ErrorCodeSignaled is a job terminated by a signal
+	ErrorCodeSignaled
+	// ErrorCodeTimeout is a job stopped for exceeding its timeout
+	ErrorCodeTimeout
+	// ErrorCodeOutputLimit is a job killed for exceeding its output limit
+	ErrorCodeOutputLimit
+	// ErrorCodeCancelled is a job removed from the queue before it started
+	ErrorCodeCancelled
+	// ErrorCodeLost is a job that was running, or queued and could not be requeued, when the server stopped
+	ErrorCodeLost
+)
+
+// String is a convienient way to convert an error code to string
+func (c ErrorCode) String() string {
+	switch c {
+	case ErrorCodeStartFailed:
+		return "start_failed"
+	case ErrorCodeExitStatus:
+		return "exit_status"
+	case ErrorCodeSignaled:
+		return "signaled"
+	case ErrorCodeTimeout:
+		return "timeout"
+	case ErrorCodeOutputLimit:
+		return "output_limit"
+	case ErrorCodeCancelled:
+		return "cancelled"
+	case ErrorCodeLost:
+		return "lost"
+	default:
+		return "internal"
+	}
+}
+
+// ParseErrorCode converts the string form of an error code back to an ErrorCode,
+// unknown codes are internal
+func ParseErrorCode(s string) ErrorCode {
+	for c := ErrorCodeInternal; c <= ErrorCodeLost; c++ {
+		if c.String() == s {
+			return c
+		}
+	}
+	return ErrorCodeInternal
+}
+
+// JobError is why a job finished without completing. Message is safe to show to
+// the client while Err is the underlying error which may reveal server details.
+type JobError struct {
+	Code    ErrorCode
+	Message string
+	Err     error
+}
+
+// Error returns the message of the error
+func (e *JobError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying error
+func (e *JobError) Unwrap() error {
+	return e.Err
+}
+
+// startFailure marks an error from starting the process of a job, any other error
+// before the job is running is internal
+type startFailure struct {
+	err error
+}
+
+// Error returns the underlying error message
+func (e *startFailure) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *startFailure) Unwrap() error {
+	return e.err
+}
+
+// startError describes why the process of command could not be started
+func startError(command string, err error) *JobError {
+	msg := fmt.Sprintf("unable to start %v", command)
+	var ie *initError
+	if !errors.As(err, &ie) {
+		return &JobError{Code: ErrorCodeStartFailed, Message: msg, Err: err}
+	}
+	switch ie.Op {
+	case initOpLookup:
+		if errors.Is(err, os.ErrNotExist) {
+			msg = fmt.Sprintf("command not found: %v", command)
+		} else if errors.Is(err, os.ErrPermission) {
+			msg = fmt.Sprintf("permission denied: %v", command)
+		}
+	case initOpChdir:
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			msg = fmt.Sprintf("working directory not found: %v", ie.Path)
+		case errors.Is(err, syscall.ENOTDIR):
+			msg = fmt.Sprintf("working directory is not a directory: %v", ie.Path)
+		case errors.Is(err, os.ErrPermission):
+			msg = fmt.Sprintf("permission denied: %v", ie.Path)
+		}
+	case initOpExec:
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			// The command exists so it is the interpreter or dynamic loader it names that is missing
+			msg = fmt.Sprintf("interpreter not found: %v", command)
+		case errors.Is(err, os.ErrPermission):
+			msg = fmt.Sprintf("permission denied: %v", command)
+		case errors.Is(err, syscall.ENOEXEC):
+			msg = fmt.Sprintf("exec format error: %v", command)
+		}
+	}
+	return &JobError{Code: ErrorCodeStartFailed, Message: msg, Err: err}
+}
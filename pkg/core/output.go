@@ -1,45 +1,772 @@
 package core
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-// OutputBuffer provides
-type OutputBuffer struct {
-	name string
+// Stream identifies the output stream a chunk was written to
+type Stream uint8
+
+const (
+	// Stdout is the standard output of a job, and its terminal output when it has a tty
+	Stdout Stream = iota + 1
+	// Stderr is the standard error of a job
+	Stderr
+)
+
+// String is a convienient way to convert a stream to string
+func (s Stream) String() string {
+	switch s {
+	case Stdout:
+		return "stdout"
+	case Stderr:
+		return "stderr"
+	default:
+		return "unknown"
+	}
+}
+
+// OutputPolicy selects what happens once a job writes more output than its limit
+type OutputPolicy int
+
+const (
+	// OutputTruncate drops any output past the limit
+	OutputTruncate OutputPolicy = iota
+	// OutputRotate splits the output into segments and removes the oldest once
+	// the limit is reached so the most recent output is kept
+	OutputRotate
+	// OutputKill kills the job once it reaches the limit
+	OutputKill
+)
+
+// String is a convienient way to convert an output policy to string
+func (p OutputPolicy) String() string {
+	switch p {
+	case OutputRotate:
+		return "rotate"
+	case OutputKill:
+		return "kill"
+	default:
+		return "truncate"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (p OutputPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (p *OutputPolicy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "truncate":
+		*p = OutputTruncate
+	case "rotate":
+		*p = OutputRotate
+	case "kill":
+		*p = OutputKill
+	default:
+		return fmt.Errorf("unknown output policy %q", text)
+	}
+	return nil
+}
+
+// DefaultOutputSegments is the number of segments kept by the rotate policy when unset
+const DefaultOutputSegments = 4
+
+// OutputSegmentSize is the size output is split into segments at, except by the rotate
+// policy which splits its limit evenly across its segments
+const OutputSegmentSize = 1 << 20
+
+// OutputLimit caps the output stored for a job
+type OutputLimit struct {
+	// MaxBytes is the most output kept, zero is unlimited
+	MaxBytes int64 `json:"max_bytes"`
+	// Policy is applied once MaxBytes is reached
+	Policy OutputPolicy `json:"policy"`
+	// Segments is the number of segments MaxBytes is split into by the rotate policy
+	Segments int `json:"segments"`
+}
+
+// chunkHeaderSize is the size of the stream, timestamp and length preceding each chunk
+const chunkHeaderSize = 1 + 8 + 4
+
+// errCorruptOutput is returned when the output file does not contain valid chunks
+var errCorruptOutput = errors.New("corrupt output")
+
+// Chunk is a single write to the output of a job
+type Chunk struct {
+	Stream Stream
+	Time   time.Time
+	// Offset is the position of Data in the output of both streams
+	Offset int64
+	Data   []byte
 }
 
-// NewOutputBuffer creates a OutputBuffer instance
-func NewOutputBuffer() (*OutputBuffer, error) {
+// segment is one file of the output
+type segment struct {
+	seq int
+	// offset is the output offset of the first chunk in the segment
+	offset int64
+	// size is the length of the file up to the last complete chunk
+	size int64
+	// length is the number of output bytes in the segment not counting chunk headers
+	length int64
+	// compressed is set once the segment is replaced by a gzip copy
+	compressed bool
+	// stored is the size of the compressed copy
+	stored int64
+}
 
-	// TODO: Swap out tempdir for a permanent solution
-	// This is easier to setup/cleanup
-	dir, err := ioutil.TempDir("", "job-worker-output-*")
+// name returns the file name of the segment
+func (seg segment) name(dir string) string {
+	if seg.compressed {
+		return segmentName(dir, seg.seq) + ".gz"
+	}
+	return segmentName(dir, seg.seq)
+}
+
+// OutputBuffer stores the output of a job as a sequence of chunks tagged with
+// their stream and the time they were written. The chunks are kept in numbered
+// segment files so old output can be rotated away, completed segments are
+// compressed while the last stays plain so it can be followed until the last
+// writer closes.
+type OutputBuffer struct {
+	dir string
+	// segments are the stored segments ordered by seq, there is always at least one
+	segments []segment
+	limit    OutputLimit
+	// onTruncate is called the first time output is dropped
+	onTruncate func()
+	// dropped is the number of output bytes truncated or rotated away
+	dropped int64
+	// f is the last segment opened for writing, it is shared by every writer
+	f       *os.File
+	writers int
+	// sealed is set once the last writer closes and the last segment is compressed
+	sealed bool
+	// compressing tracks the segments being compressed
+	compressing sync.WaitGroup
+	// changed is closed by the next write to wake readers waiting for output
+	changed chan struct{}
+	mu      sync.Mutex
+}
+
+// NewOutputBuffer creates a OutputBuffer instance in dir, a temporary directory is used when dir is empty
+func NewOutputBuffer(dir string) (*OutputBuffer, error) {
+	var err error
+	if dir == "" {
+		dir, err = ioutil.TempDir("", "job-worker-output-*")
+	} else {
+		err = os.MkdirAll(dir, 0700)
+	}
 	if err != nil {
 		return nil, err
 	}
-	f, err := os.Create(dir + "/log")
+	f, err := os.Create(segmentName(dir, 0))
 	if err != nil {
 		return nil, err
 	}
 	f.Close()
 
-	return &OutputBuffer{name: f.Name()}, nil
+	return &OutputBuffer{dir: dir, segments: []segment{{}}}, nil
 }
 
-// NewReader opens the file read only
-func (o *OutputBuffer) NewReader() (io.ReadCloser, error) {
-	return os.Open(o.name)
+// OpenOutputBuffer opens the existing output in dir, a chunk left incomplete
+// by a crash is ignored
+func OpenOutputBuffer(dir string) (*OutputBuffer, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	// A plain segment left next to its compressed copy by a crash is ignored
+	compressed := make(map[int]bool)
+	for _, e := range entries {
+		if seq, gz, ok := parseSegmentName(e.Name()); ok {
+			compressed[seq] = compressed[seq] || gz
+		}
+	}
+	if len(compressed) == 0 {
+		return nil, fmt.Errorf("no output in %v", dir)
+	}
+	var seqs []int
+	for seq := range compressed {
+		seqs = append(seqs, seq)
+	}
+	sort.Ints(seqs)
+
+	o := &OutputBuffer{dir: dir}
+	var offset int64
+	for _, seq := range seqs {
+		seg := segment{seq: seq, offset: offset, compressed: compressed[seq]}
+		if err = scanSegment(dir, &seg); err != nil {
+			return nil, err
+		}
+		offset += seg.length
+		o.segments = append(o.segments, seg)
+	}
+	return o, nil
 }
 
-// NewWriter opens the file write only
-func (o *OutputBuffer) NewWriter() (io.WriteCloser, error) {
-	return os.OpenFile(o.name, os.O_WRONLY, os.ModeAppend)
+// scanSegment finds the size and output length of the complete chunks in a segment file
+func scanSegment(dir string, seg *segment) error {
+	f, err := os.Open(seg.name(dir))
+	if err != nil {
+		return err
+	}
+	if seg.compressed {
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return err
+		}
+		seg.stored = info.Size()
+	}
+	sf, err := readSegment(f, seg.compressed)
+	if err != nil {
+		return err
+	}
+	defer sf.Close()
+
+	// The size of a compressed segment is not known until it is read, so chunks are
+	// read until one is cut short or the segment ends
+	var header [chunkHeaderSize]byte
+	for {
+		if _, err = sf.ReadAt(header[:], seg.size); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		length := int64(binary.BigEndian.Uint32(header[9:]))
+		if length > 0 {
+			if _, err = sf.ReadAt(header[:1], seg.size+chunkHeaderSize+length-1); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+		seg.size += chunkHeaderSize + length
+		seg.length += length
+	}
 }
 
-// Remove the file
+// segmentName returns the file name of a plain segment, the first is named log and the rest log.<seq>
+func segmentName(dir string, seq int) string {
+	if seq == 0 {
+		return filepath.Join(dir, "log")
+	}
+	return filepath.Join(dir, "log."+strconv.Itoa(seq))
+}
+
+// parseSegmentName returns the seq of a segment file name and if it is compressed
+func parseSegmentName(name string) (int, bool, bool) {
+	compressed := strings.HasSuffix(name, ".gz")
+	name = strings.TrimSuffix(name, ".gz")
+	if name == "log" {
+		return 0, compressed, true
+	}
+	if !strings.HasPrefix(name, "log.") {
+		return 0, false, false
+	}
+	seq, err := strconv.Atoi(strings.TrimPrefix(name, "log."))
+	return seq, compressed, err == nil && seq > 0
+}
+
+// SetLimit caps the output, onTruncate is called in a new goroutine the first time output is dropped
+func (o *OutputBuffer) SetLimit(limit OutputLimit, onTruncate func()) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if limit.Policy == OutputRotate && limit.Segments <= 0 {
+		limit.Segments = DefaultOutputSegments
+	}
+	// Every segment must hold at least one byte
+	if limit.Policy == OutputRotate && limit.MaxBytes > 0 && limit.MaxBytes < int64(limit.Segments) {
+		limit.Segments = int(limit.MaxBytes)
+	}
+	o.limit = limit
+	o.onTruncate = onTruncate
+}
+
+// Dir returns the directory holding the output segments
+func (o *OutputBuffer) Dir() string {
+	return o.dir
+}
+
+// NewReader opens the output for reading from the first chunk still stored
+func (o *OutputBuffer) NewReader() (*OutputReader, error) {
+	r := &OutputReader{o: o}
+	if _, err := r.next(0); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewWriter opens the output for writing chunks of stream
+func (o *OutputBuffer) NewWriter(stream Stream) (io.WriteCloser, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.f == nil {
+		last := o.segments[len(o.segments)-1]
+		if last.length > 0 && (last.compressed || o.sealed) {
+			// A compressed segment can not be appended to so writing continues in a new one
+			if err := o.rotate(); err != nil {
+				return nil, err
+			}
+		} else {
+			f, err := os.OpenFile(segmentName(o.dir, last.seq), os.O_WRONLY|os.O_APPEND, 0)
+			if err != nil {
+				return nil, err
+			}
+			o.f = f
+		}
+	}
+	o.sealed = false
+	o.writers++
+	return &outputWriter{o: o, stream: stream}, nil
+}
+
+// Remove deletes the output and its directory
 func (o *OutputBuffer) Remove() error {
-	return os.Remove(o.name)
+	return os.RemoveAll(o.dir)
+}
+
+// Size returns the bytes used on disk by the output
+func (o *OutputBuffer) Size() int64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	var size int64
+	for _, seg := range o.segments {
+		if seg.compressed {
+			size += seg.stored
+		} else {
+			size += seg.size
+		}
+	}
+	return size
+}
+
+// Truncated returns the number of output bytes dropped by the output limit
+func (o *OutputBuffer) Truncated() int64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.dropped
+}
+
+// Changed returns a channel closed once more output is written. It must be
+// called before reading to be sure not to miss a write.
+func (o *OutputBuffer) Changed() <-chan struct{} {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.changed == nil {
+		o.changed = make(chan struct{})
+	}
+	return o.changed
+}
+
+// segment returns the stored segment seq
+func (o *OutputBuffer) segment(seq int) (segment, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, seg := range o.segments {
+		if seg.seq == seq {
+			return seg, true
+		}
+	}
+	return segment{}, false
+}
+
+// segmentSize returns the size at which a new segment is started, o.mu must be held
+func (o *OutputBuffer) segmentSize() int64 {
+	if o.limit.MaxBytes > 0 && o.limit.Policy == OutputRotate {
+		return o.limit.MaxBytes / int64(o.limit.Segments)
+	}
+	return OutputSegmentSize
+}
+
+// write appends b as chunks applying the output limit, b is split so no segment
+// grows past the segment size. o.mu must be held.
+func (o *OutputBuffer) write(stream Stream, b []byte) error {
+	last := &o.segments[len(o.segments)-1]
+	if o.limit.MaxBytes > 0 && o.limit.Policy != OutputRotate {
+		// Keep the output up to the limit and drop the rest
+		room := o.limit.MaxBytes - (last.offset + last.length)
+		if room < 0 {
+			room = 0
+		}
+		if room < int64(len(b)) {
+			o.dropped += int64(len(b)) - room
+			b = b[:room]
+			o.truncated()
+		}
+	}
+
+	now := time.Now()
+	for len(b) > 0 {
+		last = &o.segments[len(o.segments)-1]
+		room := o.segmentSize() - last.length
+		if room <= 0 {
+			// The segment is full so the rest starts the next one
+			if err := o.rotate(); err != nil {
+				return err
+			}
+			continue
+		}
+		if room > int64(len(b)) {
+			room = int64(len(b))
+		}
+		if err := o.writeChunk(last, stream, now, b[:room]); err != nil {
+			return err
+		}
+		b = b[room:]
+	}
+	return nil
+}
+
+// writeChunk appends b to the last segment as a single chunk, o.mu must be held
+func (o *OutputBuffer) writeChunk(last *segment, stream Stream, t time.Time, b []byte) error {
+	buf := make([]byte, chunkHeaderSize+len(b))
+	buf[0] = byte(stream)
+	binary.BigEndian.PutUint64(buf[1:], uint64(t.UnixNano()))
+	binary.BigEndian.PutUint32(buf[9:], uint32(len(b)))
+	copy(buf[chunkHeaderSize:], b)
+
+	n, err := o.f.Write(buf)
+	last.size += int64(n)
+	if err != nil {
+		return err
+	}
+	last.length += int64(len(b))
+	return nil
+}
+
+// truncated calls onTruncate the first time output is dropped, o.mu must be held
+func (o *OutputBuffer) truncated() {
+	if o.onTruncate != nil {
+		go o.onTruncate()
+		o.onTruncate = nil
+	}
+}
+
+// rotate starts a new segment, compresses the previous one and removes the oldest
+// segments past the limit of the rotate policy, o.mu must be held
+func (o *OutputBuffer) rotate() error {
+	last := o.segments[len(o.segments)-1]
+	next := segment{seq: last.seq + 1, offset: last.offset + last.length}
+	f, err := os.OpenFile(segmentName(o.dir, next.seq), os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	// The previous segment was already compressed when its last writer closed
+	compress := o.f != nil
+	if o.f != nil {
+		o.f.Close()
+	}
+	o.f = f
+	o.segments = append(o.segments, next)
+
+	for o.limit.MaxBytes > 0 && o.limit.Policy == OutputRotate && len(o.segments) > o.limit.Segments {
+		// The segment may be part way through being compressed
+		name := segmentName(o.dir, o.segments[0].seq)
+		for _, name := range []string{name, name + ".gz"} {
+			if err = os.Remove(name); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+		o.dropped += o.segments[0].length
+		o.segments = o.segments[1:]
+		o.truncated()
+	}
+	if compress {
+		o.compressing.Add(1)
+		go o.compress(last)
+	}
+	return nil
+}
+
+// outputWriter writes each call to Write as a chunk
+type outputWriter struct {
+	o      *OutputBuffer
+	stream Stream
+}
+
+// Write implements io.Writer, output dropped by the limit is still reported as written
+func (w *outputWriter) Write(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	// Chunks from concurrent writers must not interleave
+	w.o.mu.Lock()
+	defer w.o.mu.Unlock()
+	err := w.o.write(w.stream, b)
+	if w.o.changed != nil {
+		close(w.o.changed)
+		w.o.changed = nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Close implements io.Closer, the last writer closes the segment, compresses it as
+// no more output is expected and waits for every segment to be compressed
+func (w *outputWriter) Close() error {
+	w.o.mu.Lock()
+	w.o.writers--
+	if w.o.writers > 0 {
+		w.o.mu.Unlock()
+		return nil
+	}
+	err := w.o.f.Close()
+	w.o.f = nil
+	if last := w.o.segments[len(w.o.segments)-1]; last.length > 0 {
+		w.o.sealed = true
+		w.o.compressing.Add(1)
+		go w.o.compress(last)
+	}
+	w.o.mu.Unlock()
+
+	w.o.compressing.Wait()
+	return err
+}
+
+// OutputReader reads the chunks of an OutputBuffer across its segments. Only complete
+// chunks are read, io.EOF is returned once the reader has caught up with the writers.
+// Output rotated away before it is read is skipped.
+type OutputReader struct {
+	o   *OutputBuffer
+	f   segmentFile
+	seq int
+	// off is the file offset of the next chunk in the segment
+	off int64
+	// pos is the output offset of the next chunk
+	pos int64
+	// pending is the part of the current chunk not yet returned
+	pending Chunk
+}
+
+// open moves to the start of the segment chosen by pick and reports if one was chosen
+func (r *OutputReader) open(pick func([]segment) (segment, bool)) (segment, bool, error) {
+	// The file is opened with the lock held so it is not removed or replaced first
+	r.o.mu.Lock()
+	seg, ok := pick(r.o.segments)
+	var f *os.File
+	var err error
+	if ok {
+		f, err = os.Open(seg.name(r.o.dir))
+	}
+	r.o.mu.Unlock()
+	if !ok || err != nil {
+		return seg, ok, err
+	}
+
+	sf, err := readSegment(f, seg.compressed)
+	if err != nil {
+		return seg, ok, err
+	}
+	if r.f != nil {
+		r.f.Close()
+	}
+	r.f, r.seq, r.off, r.pos = sf, seg.seq, 0, seg.offset
+	r.pending = Chunk{}
+	return seg, ok, nil
+}
+
+// next opens the first stored segment at or after seq and reports if there was one
+func (r *OutputReader) next(seq int) (bool, error) {
+	_, ok, err := r.open(func(segments []segment) (segment, bool) {
+		for _, seg := range segments {
+			if seg.seq >= seq {
+				return seg, true
+			}
+		}
+		return segment{}, false
+	})
+	return ok, err
+}
+
+// Next returns the next chunk
+func (r *OutputReader) Next() (Chunk, error) {
+	if len(r.pending.Data) > 0 {
+		chunk := r.pending
+		r.pending = Chunk{}
+		return chunk, nil
+	}
+	chunk, err := r.header()
+	if err != nil {
+		return Chunk{}, err
+	}
+	if _, err := r.f.ReadAt(chunk.Data, r.off+chunkHeaderSize); err != nil {
+		return Chunk{}, err
+	}
+	r.skip(chunk)
+	return chunk, nil
+}
+
+// header reads the next chunk without its data, moving on to the next segment once
+// the current one is read or was removed
+func (r *OutputReader) header() (Chunk, error) {
+	for {
+		seq := r.seq
+		if seg, ok := r.o.segment(r.seq); ok {
+			if r.off+chunkHeaderSize <= seg.size {
+				break
+			}
+			seq++
+		}
+		ok, err := r.next(seq)
+		if err != nil {
+			return Chunk{}, err
+		} else if !ok {
+			return Chunk{}, io.EOF
+		}
+	}
+
+	var header [chunkHeaderSize]byte
+	if _, err := r.f.ReadAt(header[:], r.off); err != nil {
+		return Chunk{}, err
+	}
+	stream := Stream(header[0])
+	if stream != Stdout && stream != Stderr {
+		return Chunk{}, errCorruptOutput
+	}
+	return Chunk{
+		Stream: stream,
+		Time:   time.Unix(0, int64(binary.BigEndian.Uint64(header[1:]))),
+		Offset: r.pos,
+		Data:   make([]byte, binary.BigEndian.Uint32(header[9:])),
+	}, nil
+}
+
+// skip moves past a chunk returned by header
+func (r *OutputReader) skip(chunk Chunk) {
+	r.off += chunkHeaderSize + int64(len(chunk.Data))
+	r.pos += int64(len(chunk.Data))
+}
+
+// Read implements io.Reader returning the data of both streams in the order it was written
+func (r *OutputReader) Read(b []byte) (int, error) {
+	if len(r.pending.Data) == 0 {
+		chunk, err := r.Next()
+		if err != nil {
+			return 0, err
+		}
+		r.pending = chunk
+	}
+	n := copy(b, r.pending.Data)
+	r.pending.Data = r.pending.Data[n:]
+	r.pending.Offset += int64(n)
+	return n, nil
+}
+
+// Offset returns the output offset of the next byte to be read
+func (r *OutputReader) Offset() int64 {
+	if len(r.pending.Data) > 0 {
+		return r.pending.Offset
+	}
+	return r.pos
+}
+
+// SeekOffset moves to an offset in the output of both streams, an offset past the
+// output written so far moves to the end and one rotated away moves to the first
+// chunk still stored
+func (r *OutputReader) SeekOffset(offset int64) error {
+	// Start from the last segment beginning at or before offset
+	_, _, err := r.open(func(segments []segment) (segment, bool) {
+		start := segments[0]
+		for _, seg := range segments {
+			if seg.offset <= offset {
+				start = seg
+			}
+		}
+		return start, true
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		chunk, err := r.header()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if end := r.pos + int64(len(chunk.Data)); end <= offset {
+			r.skip(chunk)
+			continue
+		}
+		// Keep the part of the chunk at and after offset
+		chunk, err = r.Next()
+		if err != nil {
+			return err
+		}
+		if offset > chunk.Offset {
+			chunk.Data = chunk.Data[offset-chunk.Offset:]
+			chunk.Offset = offset
+		}
+		r.pending = chunk
+		return nil
+	}
+}
+
+// SeekTail moves to the start of the last n lines written so far
+func (r *OutputReader) SeekTail(n int) error {
+	if err := r.SeekOffset(0); err != nil {
+		return err
+	}
+	if n <= 0 {
+		return r.SeekEnd()
+	}
+
+	// Remember where the last n lines start, a trailing newline does not start a line
+	starts := []int64{r.Offset()}
+	var last byte
+	for {
+		chunk, err := r.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		for i, c := range chunk.Data {
+			if last == '\n' {
+				starts = append(starts, chunk.Offset+int64(i))
+				if len(starts) > n {
+					starts = starts[1:]
+				}
+			}
+			last = c
+		}
+	}
+	return r.SeekOffset(starts[0])
+}
+
+// SeekEnd skips every chunk written so far
+func (r *OutputReader) SeekEnd() error {
+	last, _, err := r.open(func(segments []segment) (segment, bool) {
+		return segments[len(segments)-1], true
+	})
+	if err != nil {
+		return err
+	}
+	r.off = last.size
+	r.pos = last.offset + last.length
+	return nil
+}
+
+// Close implements io.Closer
+func (r *OutputReader) Close() error {
+	return r.f.Close()
 }
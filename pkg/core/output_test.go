@@ -0,0 +1,353 @@
+package core_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dboslee/job-worker/pkg/core"
+)
+
+func TestOutputStreams(t *testing.T) {
+	buf, err := core.NewOutputBuffer("")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer buf.Remove()
+
+	stdout, _ := buf.NewWriter(core.Stdout)
+	defer stdout.Close()
+	stderr, _ := buf.NewWriter(core.Stderr)
+	defer stderr.Close()
+
+	r, _ := buf.NewReader()
+	defer r.Close()
+	if _, err = r.Next(); err != io.EOF {
+		t.Errorf("expected EOF from empty output got: %v", err)
+	}
+
+	stdout.Write([]byte("out1"))
+	stderr.Write([]byte("err1"))
+	stdout.Write([]byte("out2"))
+
+	want := []struct {
+		stream core.Stream
+		data   string
+	}{
+		{core.Stdout, "out1"},
+		{core.Stderr, "err1"},
+		{core.Stdout, "out2"},
+	}
+	for _, w := range want {
+		chunk, err := r.Next()
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if chunk.Stream != w.stream || string(chunk.Data) != w.data {
+			t.Errorf("chunk want: %v %q got: %v %q", w.stream, w.data, chunk.Stream, chunk.Data)
+		}
+		if chunk.Time.IsZero() {
+			t.Errorf("chunk has no timestamp")
+		}
+	}
+
+	// Read returns the data of every chunk in order
+	r2, _ := buf.NewReader()
+	defer r2.Close()
+	b, _ := ioutil.ReadAll(r2)
+	if string(b) != "out1err1out2" {
+		t.Errorf("output want: %q got: %q", "out1err1out2", b)
+	}
+
+	r2.SeekEnd()
+	stderr.Write([]byte("err2"))
+	if chunk, _ := r2.Next(); string(chunk.Data) != "err2" {
+		t.Errorf("expected only new output after SeekEnd got: %q", chunk.Data)
+	}
+}
+
+func TestOutputChanged(t *testing.T) {
+	buf, err := core.NewOutputBuffer("")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer buf.Remove()
+	w, _ := buf.NewWriter(core.Stdout)
+	defer w.Close()
+
+	changed := buf.Changed()
+	select {
+	case <-changed:
+		t.Fatal("changed before any write")
+	default:
+	}
+
+	go w.Write([]byte("hello"))
+	select {
+	case <-changed:
+	case <-time.After(time.Second):
+		t.Fatal("write did not wake waiting readers")
+	}
+	if next := buf.Changed(); next == changed {
+		t.Errorf("expected a new channel after a write")
+	}
+}
+
+func TestOutputSeek(t *testing.T) {
+	buf, err := core.NewOutputBuffer("")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer buf.Remove()
+	stdout, _ := buf.NewWriter(core.Stdout)
+	defer stdout.Close()
+	stderr, _ := buf.NewWriter(core.Stderr)
+	defer stderr.Close()
+	stdout.Write([]byte("a\nb"))
+	stderr.Write([]byte("\nc\n"))
+	stdout.Write([]byte("d\n"))
+
+	r, _ := buf.NewReader()
+	defer r.Close()
+	tests := []struct {
+		seek func() error
+		want string
+	}{
+		{func() error { return r.SeekOffset(0) }, "a\nb\nc\nd\n"},
+		{func() error { return r.SeekOffset(2) }, "b\nc\nd\n"},
+		{func() error { return r.SeekOffset(4) }, "c\nd\n"},
+		{func() error { return r.SeekOffset(100) }, ""},
+		{func() error { return r.SeekTail(1) }, "d\n"},
+		{func() error { return r.SeekTail(3) }, "b\nc\nd\n"},
+		{func() error { return r.SeekTail(10) }, "a\nb\nc\nd\n"},
+	}
+	for i, test := range tests {
+		if err := test.seek(); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		offset := r.Offset()
+		b, _ := ioutil.ReadAll(r)
+		if string(b) != test.want {
+			t.Errorf("%v: output want: %q got: %q", i, test.want, b)
+		}
+		if want := int64(8 - len(test.want)); offset != want {
+			t.Errorf("%v: offset want: %v got: %v", i, want, offset)
+		}
+	}
+}
+
+func TestOutputTruncate(t *testing.T) {
+	buf, err := core.NewOutputBuffer("")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer buf.Remove()
+	buf.SetLimit(core.OutputLimit{MaxBytes: 6}, nil)
+	w, _ := buf.NewWriter(core.Stdout)
+	defer w.Close()
+
+	for _, s := range []string{"abcd", "efgh", "ijkl"} {
+		if n, err := w.Write([]byte(s)); n != len(s) || err != nil {
+			t.Errorf("expected dropped output to be reported as written got: %v %v", n, err)
+		}
+	}
+	r, _ := buf.NewReader()
+	defer r.Close()
+	if b, _ := ioutil.ReadAll(r); string(b) != "abcdef" {
+		t.Errorf("output want: %q got: %q", "abcdef", b)
+	}
+	if dropped := buf.Truncated(); dropped != 6 {
+		t.Errorf("dropped want: 6 got: %v", dropped)
+	}
+}
+
+func TestOutputRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "job-worker-test-*")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	buf, err := core.NewOutputBuffer(dir)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer buf.Remove()
+	buf.SetLimit(core.OutputLimit{MaxBytes: 8, Policy: core.OutputRotate, Segments: 2}, nil)
+	w, _ := buf.NewWriter(core.Stdout)
+
+	// A reader left on a segment that is rotated away skips to the oldest kept
+	slow, _ := buf.NewReader()
+	defer slow.Close()
+	if _, err := w.Write([]byte("0123")); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r, _ := buf.NewReader()
+	defer r.Close()
+	if chunk, _ := r.Next(); string(chunk.Data) != "0123" {
+		t.Errorf("chunk want: %q got: %q", "0123", chunk.Data)
+	}
+	for _, s := range []string{"4567", "89ab", "cdef"} {
+		w.Write([]byte(s))
+	}
+	w.Close()
+
+	// Only the last two segments are kept
+	want := "89abcdef"
+	if b, _ := ioutil.ReadAll(slow); string(b) != want {
+		t.Errorf("output want: %q got: %q", want, b)
+	}
+	if b, _ := ioutil.ReadAll(r); string(b) != want {
+		t.Errorf("output want: %q got: %q", want, b)
+	}
+	if dropped := buf.Truncated(); dropped != 8 {
+		t.Errorf("dropped want: 8 got: %v", dropped)
+	}
+	if err := r.SeekOffset(2); err != nil || r.Offset() != 8 {
+		t.Errorf("expected seek to rotated output to move to offset 8 got: %v %v", r.Offset(), err)
+	}
+	if err := r.SeekOffset(13); err != nil || r.Offset() != 13 {
+		t.Errorf("expected seek to offset 13 got: %v %v", r.Offset(), err)
+	}
+	if err := r.SeekTail(1); err != nil || r.Offset() != 8 {
+		t.Errorf("expected tail to start at offset 8 got: %v %v", r.Offset(), err)
+	}
+
+	// The segments are found again when the output is reopened
+	reopened, err := core.OpenOutputBuffer(dir)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r2, _ := reopened.NewReader()
+	defer r2.Close()
+	if b, _ := ioutil.ReadAll(r2); string(b) != want {
+		t.Errorf("reopened output want: %q got: %q", want, b)
+	}
+}
+
+func TestOutputRotateSplit(t *testing.T) {
+	buf, err := core.NewOutputBuffer("")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer buf.Remove()
+	buf.SetLimit(core.OutputLimit{MaxBytes: 8, Policy: core.OutputRotate, Segments: 2}, nil)
+	w, _ := buf.NewWriter(core.Stdout)
+
+	// A write larger than a segment is split at the segment boundaries so no
+	// more than the limit is kept
+	w.Write([]byte("0123456789"))
+	r, _ := buf.NewReader()
+	defer r.Close()
+	var got []string
+	for {
+		chunk, err := r.Next()
+		if err != nil {
+			break
+		}
+		got = append(got, string(chunk.Data))
+	}
+	w.Close()
+	if want := []string{"4567", "89"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("chunks want: %q got: %q", want, got)
+	}
+	if dropped := buf.Truncated(); dropped != 4 {
+		t.Errorf("dropped want: 4 got: %v", dropped)
+	}
+}
+
+func TestOutputCompress(t *testing.T) {
+	buf, err := core.NewOutputBuffer("")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer buf.Remove()
+	buf.SetLimit(core.OutputLimit{MaxBytes: 3 << 16, Policy: core.OutputRotate, Segments: 3}, nil)
+	w, _ := buf.NewWriter(core.Stdout)
+	r, _ := buf.NewReader()
+	defer r.Close()
+
+	saved := core.CompressedBytesSaved()
+	line := []byte(strings.Repeat("compressible ", 8) + "\n")
+	var want bytes.Buffer
+	for want.Len() < 2<<16+1000 {
+		w.Write(line)
+		want.Write(line)
+	}
+	// Close waits for the completed segments to be compressed
+	w.Close()
+
+	if _, err := os.Stat(filepath.Join(buf.Dir(), "log.gz")); err != nil {
+		t.Errorf("expected first segment to be compressed %v", err)
+	}
+	// The last segment is compressed too once the last writer closes
+	if _, err := os.Stat(filepath.Join(buf.Dir(), "log.2.gz")); err != nil {
+		t.Errorf("expected last segment to be compressed %v", err)
+	}
+	if size := buf.Size(); size >= int64(want.Len()) {
+		t.Errorf("expected compressed size below %v got: %v", want.Len(), size)
+	}
+	if core.CompressedBytesSaved() <= saved {
+		t.Errorf("expected compressed bytes saved to increase")
+	}
+
+	if b, _ := ioutil.ReadAll(r); !bytes.Equal(b, want.Bytes()) {
+		t.Errorf("output differs after compression got %v bytes want: %v", len(b), want.Len())
+	}
+	reopened, err := core.OpenOutputBuffer(buf.Dir())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r2, _ := reopened.NewReader()
+	defer r2.Close()
+	if err := r2.SeekOffset(int64(want.Len()) - 10); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if b, _ := ioutil.ReadAll(r2); !bytes.Equal(b, want.Bytes()[want.Len()-10:]) {
+		t.Errorf("reopened output want: %q got: %q", want.Bytes()[want.Len()-10:], b)
+	}
+	if size := reopened.Size(); size != buf.Size() {
+		t.Errorf("reopened size want: %v got: %v", buf.Size(), size)
+	}
+}
+
+func TestOutputSegments(t *testing.T) {
+	buf, err := core.NewOutputBuffer("")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	defer buf.Remove()
+	w, _ := buf.NewWriter(core.Stdout)
+
+	// Output is split into segments without any limit
+	line := []byte(strings.Repeat("segment ", 16) + "\n")
+	var want bytes.Buffer
+	for want.Len() < core.OutputSegmentSize+1000 {
+		w.Write(line)
+		want.Write(line)
+	}
+	w.Close()
+	for _, name := range []string{"log.gz", "log.1.gz"} {
+		if _, err := os.Stat(filepath.Join(buf.Dir(), name)); err != nil {
+			t.Errorf("expected compressed segment %v", err)
+		}
+	}
+
+	// Writing after the last writer closed continues in a new segment
+	w, _ = buf.NewWriter(core.Stderr)
+	w.Write([]byte("more\n"))
+	want.WriteString("more\n")
+	w.Close()
+	if _, err := os.Stat(filepath.Join(buf.Dir(), "log.2.gz")); err != nil {
+		t.Errorf("expected new compressed segment %v", err)
+	}
+
+	r, _ := buf.NewReader()
+	defer r.Close()
+	if b, _ := ioutil.ReadAll(r); !bytes.Equal(b, want.Bytes()) {
+		t.Errorf("output differs got %v bytes want: %v", len(b), want.Len())
+	}
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"io/ioutil"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// prSetChildSubreaper is the prctl option PR_SET_CHILD_SUBREAPER
+const prSetChildSubreaper = 36
+
+var subreaperOnce sync.Once
+
+// becomeSubreaper makes orphaned descendants children of this process so they can be reaped
+func becomeSubreaper() {
+	subreaperOnce.Do(func() {
+		_, _, errno := syscall.RawSyscall(syscall.SYS_PRCTL, prSetChildSubreaper, 1, 0)
+		if errno != 0 {
+			log.Printf("unable to become child subreaper %v", errno)
+		}
+	})
+}
+
+// killTree sends SIGKILL to every descendant of pid. The descendants are killed before
+// pid so they can not be reparented out of its tree, processes forked while the tree is
+// killed are found by walking it again.
+func killTree(pid int) {
+	for i := 0; i < 10; i++ {
+		pids := descendants(pid)
+		if len(pids) == 0 {
+			break
+		}
+		for _, child := range pids {
+			syscall.Kill(child, syscall.SIGKILL)
+		}
+	}
+	syscall.Kill(pid, syscall.SIGKILL)
+}
+
+// descendants returns the pids of every descendant of pid by walking /proc
+func descendants(pid int) []int {
+	entries, err := ioutil.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+	children := make(map[int][]int)
+	for _, e := range entries {
+		child, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		b, err := ioutil.ReadFile("/proc/" + e.Name() + "/stat")
+		if err != nil {
+			continue
+		}
+		// The command name may contain spaces so parse after its closing paren
+		fields := strings.Fields(string(b[strings.LastIndexByte(string(b), ')')+1:]))
+		if len(fields) < 2 {
+			continue
+		}
+		ppid, _ := strconv.Atoi(fields[1])
+		children[ppid] = append(children[ppid], child)
+	}
+
+	var pids []int
+	queue := children[pid]
+	for len(queue) > 0 {
+		pids = append(pids, queue[0])
+		queue = append(queue[1:], children[queue[0]]...)
+	}
+	return pids
+}
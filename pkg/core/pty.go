@@ -0,0 +1,68 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// Winsize is the size of a terminal in characters
+type Winsize struct {
+	Rows uint16
+	Cols uint16
+	_    [2]uint16
+}
+
+// openPty opens a new pseudo-terminal returning its master and slave ends
+func openPty() (*os.File, *os.File, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	var unlock int32
+	if err = ioctl(master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+	var n uint32
+	if err = ioctl(master.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+	slave, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, nil, err
+	}
+	return master, slave, nil
+}
+
+// setWinsize sets the size of the terminal f
+func setWinsize(f *os.File, size Winsize) error {
+	return ioctl(f.Fd(), syscall.TIOCSWINSZ, uintptr(unsafe.Pointer(&size)))
+}
+
+// ioctl performs an ioctl returning errno as an error
+func ioctl(fd uintptr, req uintptr, arg uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, arg); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// ptyReader reads the master of a pseudo-terminal, the EIO returned once every
+// slave is closed is reported as EOF
+type ptyReader struct {
+	master *os.File
+}
+
+// Read implements io.Reader
+func (r ptyReader) Read(b []byte) (int, error) {
+	n, err := r.master.Read(b)
+	if pe, ok := err.(*os.PathError); ok && pe.Err == syscall.EIO {
+		return n, io.EOF
+	}
+	return n, err
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"time"
+)
+
+// ErrNotFinished is returned when removing a job that has not finished
+var ErrNotFinished = errors.New("job has not finished")
+
+// Retention limits how many finished jobs and how much of their output are kept, zero values are unlimited
+type Retention struct {
+	// MaxAge is how long a job is kept after it finishes
+	MaxAge time.Duration
+	// MaxJobs is the number of finished jobs kept
+	MaxJobs int
+	// MaxBytes is the total size of the output of every job, running jobs count but are never removed
+	MaxBytes int64
+}
+
+// Expired returns the finished jobs exceeding the retention, oldest first
+func (r Retention) Expired(jobs []*Job, now time.Time) []*Job {
+	var finished []*Job
+	var total int64
+	for _, j := range jobs {
+		if j.OutputBuf != nil {
+			total += j.OutputBuf.Size()
+		}
+		if j.Status().Finished() {
+			finished = append(finished, j)
+		}
+	}
+	sort.Slice(finished, func(a, b int) bool {
+		return finished[a].FinishedAt().Before(finished[b].FinishedAt())
+	})
+
+	var expired []*Job
+	for i, j := range finished {
+		if (r.MaxAge <= 0 || now.Sub(j.FinishedAt()) <= r.MaxAge) &&
+			(r.MaxJobs <= 0 || len(finished)-i <= r.MaxJobs) &&
+			(r.MaxBytes <= 0 || total <= r.MaxBytes) {
+			// Every newer job is within the retention too
+			break
+		}
+		expired = append(expired, j)
+		if j.OutputBuf != nil {
+			total -= j.OutputBuf.Size()
+		}
+	}
+	return expired
+}
+
+// RemoveJob deletes a finished job from the store along with its output and workspace
+func RemoveJob(store JobStore, j *Job) error {
+	if !j.Status().Finished() {
+		return ErrNotFinished
+	}
+	if err := store.Delete(j.ID); err != nil {
+		return err
+	}
+	if j.OutputBuf != nil {
+		if err := j.OutputBuf.Remove(); err != nil {
+			return err
+		}
+	}
+	if j.Workspace != "" {
+		return os.RemoveAll(j.Workspace)
+	}
+	return nil
+}
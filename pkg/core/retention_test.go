@@ -0,0 +1,73 @@
+package core_test
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dboslee/job-worker/pkg/core"
+)
+
+func TestRetentionExpired(t *testing.T) {
+	var jobs []*core.Job
+	for i := 0; i < 3; i++ {
+		job, _ := core.NewJob("test-client", "echo", "hello")
+		job.Cmd = mockExec("echo", "hello")
+		job.Start()
+		defer job.OutputBuf.Remove()
+		jobs = append(jobs, job)
+		time.Sleep(time.Millisecond * 10)
+	}
+	running, _ := core.NewJob("test-client", "sleep", "5")
+	defer running.OutputBuf.Remove()
+	all := append([]*core.Job{running}, jobs...)
+
+	// Each job writes one chunk of "hello\n"
+	size := jobs[0].OutputBuf.Size()
+	tests := []struct {
+		retention core.Retention
+		now       time.Time
+		expired   int
+	}{
+		{core.Retention{}, time.Now(), 0},
+		{core.Retention{MaxJobs: 2}, time.Now(), 1},
+		{core.Retention{MaxAge: time.Minute}, time.Now(), 0},
+		{core.Retention{MaxAge: time.Minute}, time.Now().Add(time.Hour), 3},
+		{core.Retention{MaxBytes: size * 2}, time.Now(), 1},
+		{core.Retention{MaxJobs: 2, MaxBytes: size}, time.Now(), 2},
+	}
+	for _, test := range tests {
+		expired := test.retention.Expired(all, test.now)
+		if len(expired) != test.expired {
+			t.Errorf("%+v: expected %v expired jobs got: %v", test.retention, test.expired, len(expired))
+			continue
+		}
+		// Oldest jobs expire first
+		for i, j := range expired {
+			if j != jobs[i] {
+				t.Errorf("%+v: unexpected job %v expired", test.retention, i)
+			}
+		}
+	}
+}
+
+func TestRemoveJob(t *testing.T) {
+	store := core.NewMemoryStore()
+	job, _ := core.NewJobWithOutputDir("test-client", t.TempDir(), "echo", "hello")
+	job.Cmd = mockExec("echo", "hello")
+	store.Add(job)
+
+	if err := core.RemoveJob(store, job); err != core.ErrNotFinished {
+		t.Errorf("expected not finished error got: %v", err)
+	}
+	job.Start()
+	if err := core.RemoveJob(store, job); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, ok := store.Get(job.ID); ok {
+		t.Errorf("job still in store")
+	}
+	if _, err := os.Stat(job.OutputBuf.Dir()); !os.IsNotExist(err) {
+		t.Errorf("expected output to be removed got: %v", err)
+	}
+}
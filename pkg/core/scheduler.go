@@ -0,0 +1,226 @@
+package core
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrQueueFull is returned when a job is submitted to a scheduler with a full queue
+var ErrQueueFull = errors.New("job queue is full")
+
+// ErrCancelled is the error of a job removed from the queue before it started
+var ErrCancelled = errors.New("job cancelled before starting")
+
+// Scheduler bounds the number of concurrently running jobs.
+// Jobs submitted while all slots are in use are queued per client and started
+// using weighted fair-share across clients, highest priority first within a client.
+type Scheduler struct {
+	maxRunning int
+	maxQueued  int
+	running    int
+	queued     int
+	seq        uint64
+	weights    map[string]float64
+	clients    map[string]*clientQueue
+	mu         sync.Mutex
+}
+
+// clientQueue holds the queued jobs and share usage of a single client
+type clientQueue struct {
+	jobs []queuedJob
+	// usage is the number of started jobs divided by the client weight
+	usage float64
+}
+
+// queuedJob is a job waiting in a client queue
+type queuedJob struct {
+	job *Job
+	seq uint64
+}
+
+// NewScheduler creates a Scheduler, a limit of zero or less is unbounded.
+// Clients missing from weights have a weight of 1.
+func NewScheduler(maxRunning int, maxQueued int, weights map[string]float64) *Scheduler {
+	return &Scheduler{
+		maxRunning: maxRunning,
+		maxQueued:  maxQueued,
+		weights:    weights,
+		clients:    make(map[string]*clientQueue),
+	}
+}
+
+// Submit starts a job if a slot is free and queues it otherwise
+func (s *Scheduler) Submit(j *Job) error {
+	return s.submit(j, true)
+}
+
+// Resubmit queues a job restored after a restart, the queue limit is not applied
+// as the job was already accepted
+func (s *Scheduler) Resubmit(j *Job) {
+	s.submit(j, false)
+}
+
+// submit starts or queues a job, a full queue rejects it when limited is set
+func (s *Scheduler) submit(j *Job, limited bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cq := s.client(j.ClientID)
+	if s.maxRunning <= 0 || s.running < s.maxRunning {
+		j.publish(EventCreated)
+		s.start(cq, j)
+		return nil
+	}
+	if limited && s.maxQueued > 0 && s.queued >= s.maxQueued {
+		return ErrQueueFull
+	}
+	j.publish(EventCreated)
+	j.publish(EventQueued)
+
+	// A client that was idle does not get credit for the time it was idle
+	if len(cq.jobs) == 0 {
+		if min, ok := s.minUsage(); ok && cq.usage < min {
+			cq.usage = min
+		}
+	}
+
+	// Keep each client queue ordered by priority then submission order
+	s.seq++
+	qj := queuedJob{job: j, seq: s.seq}
+	i := len(cq.jobs)
+	for i > 0 && cq.jobs[i-1].job.Priority < j.Priority {
+		i--
+	}
+	cq.jobs = append(cq.jobs, queuedJob{})
+	copy(cq.jobs[i+1:], cq.jobs[i:])
+	cq.jobs[i] = qj
+	s.queued++
+	return nil
+}
+
+// Position returns the 1 based position a queued job will start in or 0 if it is not queued
+func (s *Scheduler) Position(j *Job) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cq, ok := s.clients[j.ClientID]
+	if !ok || !cq.contains(j) {
+		return 0
+	}
+
+	// Replay the selection on a copy of the queues
+	clients := make(map[string]*clientQueue, len(s.clients))
+	for id, cq := range s.clients {
+		clients[id] = &clientQueue{jobs: cq.jobs, usage: cq.usage}
+	}
+	for pos := 1; ; pos++ {
+		id, cq := next(clients)
+		if cq.jobs[0].job == j {
+			return pos
+		}
+		cq.jobs = cq.jobs[1:]
+		cq.usage += 1 / s.weight(id)
+	}
+}
+
+// Cancel removes a queued job and marks it as errored, it returns false if the job is not queued
+func (s *Scheduler) Cancel(j *Job) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cq, ok := s.clients[j.ClientID]
+	if !ok {
+		return false
+	}
+	for i, qj := range cq.jobs {
+		if qj.job == j {
+			cq.jobs = append(cq.jobs[:i], cq.jobs[i+1:]...)
+			s.queued--
+			j.cancel(ErrCancelled)
+			return true
+		}
+	}
+	return false
+}
+
+// client returns the queue of a client creating it if needed, s.mu must be held
+func (s *Scheduler) client(id string) *clientQueue {
+	cq, ok := s.clients[id]
+	if !ok {
+		cq = &clientQueue{}
+		s.clients[id] = cq
+	}
+	return cq
+}
+
+// weight returns the share weight of a client
+func (s *Scheduler) weight(id string) float64 {
+	if w, ok := s.weights[id]; ok && w > 0 {
+		return w
+	}
+	return 1
+}
+
+// minUsage returns the lowest usage of clients with queued jobs, s.mu must be held
+func (s *Scheduler) minUsage() (float64, bool) {
+	var min float64
+	found := false
+	for _, cq := range s.clients {
+		if len(cq.jobs) > 0 && (!found || cq.usage < min) {
+			min = cq.usage
+			found = true
+		}
+	}
+	return min, found
+}
+
+// start runs a job in the background, s.mu must be held
+func (s *Scheduler) start(cq *clientQueue, j *Job) {
+	s.running++
+	cq.usage += 1 / s.weight(j.ClientID)
+	go func() {
+		j.Start()
+		s.finish()
+	}()
+}
+
+// finish frees the slot of a finished job and starts the next queued job
+func (s *Scheduler) finish() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running--
+	if s.queued == 0 {
+		return
+	}
+	_, cq := next(s.clients)
+	j := cq.jobs[0].job
+	cq.jobs = cq.jobs[1:]
+	s.queued--
+	s.start(cq, j)
+}
+
+// next returns the client with queued jobs and the lowest usage, ties go to the oldest job
+func next(clients map[string]*clientQueue) (string, *clientQueue) {
+	var id string
+	var best *clientQueue
+	for cid, cq := range clients {
+		if len(cq.jobs) == 0 {
+			continue
+		}
+		if best == nil || cq.usage < best.usage ||
+			(cq.usage == best.usage && cq.jobs[0].seq < best.jobs[0].seq) {
+			id, best = cid, cq
+		}
+	}
+	return id, best
+}
+
+// contains reports if a job is in the client queue
+func (cq *clientQueue) contains(j *Job) bool {
+	for _, qj := range cq.jobs {
+		if qj.job == j {
+			return true
+		}
+	}
+	return false
+}
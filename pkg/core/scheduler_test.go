@@ -0,0 +1,149 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dboslee/job-worker/pkg/core"
+)
+
+// waitStatus waits for a job to reach status or fails the test
+func waitStatus(t *testing.T, job *core.Job, status core.JobStatus) {
+	deadline := time.Now().Add(time.Second * 5)
+	for job.Status() != status {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for status %v got: %v", status, job.Status())
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+func mockSleepJob() *core.Job {
+	return mockClientJob("test-client", 0)
+}
+
+func mockClientJob(clientID string, priority int) *core.Job {
+	job, _ := core.NewJob(clientID, "sleep", "5")
+	job.Priority = priority
+	job.Cmd = mockExec("sleep", "5")
+	return job
+}
+
+func TestSchedulerQueue(t *testing.T) {
+	scheduler := core.NewScheduler(1, 2, nil)
+	jobs := []*core.Job{mockSleepJob(), mockSleepJob(), mockSleepJob()}
+	for _, job := range jobs {
+		if err := scheduler.Submit(job); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if err := scheduler.Submit(mockSleepJob()); err != core.ErrQueueFull {
+		t.Errorf("expected queue full error got: %v", err)
+	}
+
+	waitStatus(t, jobs[0], core.Running)
+	for i, job := range jobs[1:] {
+		if pos := scheduler.Position(job); pos != i+1 {
+			t.Errorf("queue position want: %v got: %v", i+1, pos)
+		}
+	}
+
+	// Finishing the running job starts the next in line
+	jobs[0].Kill()
+	waitStatus(t, jobs[1], core.Running)
+	if pos := scheduler.Position(jobs[2]); pos != 1 {
+		t.Errorf("queue position want: 1 got: %v", pos)
+	}
+
+	if !scheduler.Cancel(jobs[2]) {
+		t.Errorf("expected queued job to be cancelled")
+	}
+	if status := jobs[2].Status(); status != core.Error {
+		t.Errorf("unexpected status got: %v want: %v", status, core.Error)
+	}
+	jobs[1].Kill()
+	waitStatus(t, jobs[1], core.Killed)
+}
+
+func TestSchedulerFairShare(t *testing.T) {
+	cases := []struct {
+		weights map[string]float64
+		order   string
+	}{
+		{nil, "abababababab"},
+		{map[string]float64{"a": 2}, "abaabaababbb"},
+	}
+
+	for _, tc := range cases {
+		scheduler := core.NewScheduler(1, 0, tc.weights)
+		bus := core.NewEventBus(core.DefaultEventHistory)
+		running := mockClientJob("a", 0)
+		scheduler.Submit(running)
+		waitStatus(t, running, core.Running)
+
+		// Both clients submit in bulk, client a first
+		var jobs []*core.Job
+		for _, client := range []string{"a", "b"} {
+			for i := 0; i < 6; i++ {
+				job, _ := core.NewJob(client, "exit", "0")
+				job.Cmd = mockExec("exit", "0")
+				job.Events = bus
+				scheduler.Submit(job)
+				jobs = append(jobs, job)
+			}
+		}
+
+		order := make([]byte, len(jobs))
+		for _, job := range jobs {
+			if pos := scheduler.Position(job); pos > 0 {
+				order[pos-1] = job.ClientID[0]
+			}
+		}
+		if got := string(order); got != tc.order {
+			t.Errorf("weights %v queue order want: %v got: %v", tc.weights, tc.order, got)
+		}
+
+		// The jobs start one at a time in the same order once the running job finishes
+		events, cancel := bus.Subscribe(0, func(e core.Event) bool {
+			return e.Type == core.EventStarted
+		})
+		running.Kill()
+		var started []byte
+		timeout := time.After(time.Second * 10)
+		for len(started) < len(jobs) {
+			select {
+			case e := <-events:
+				started = append(started, e.ClientID[0])
+			case <-timeout:
+				t.Fatalf("timed out waiting for jobs to start, started: %s", started)
+			}
+		}
+		cancel()
+		if got := string(started); got != tc.order {
+			t.Errorf("weights %v start order want: %v got: %v", tc.weights, tc.order, got)
+		}
+	}
+}
+
+func TestSchedulerPriority(t *testing.T) {
+	scheduler := core.NewScheduler(1, 0, nil)
+	running := mockClientJob("a", 0)
+	scheduler.Submit(running)
+
+	low := mockClientJob("a", 0)
+	high := mockClientJob("a", 10)
+	scheduler.Submit(low)
+	scheduler.Submit(high)
+	if pos := scheduler.Position(high); pos != 1 {
+		t.Errorf("high priority position want: 1 got: %v", pos)
+	}
+	if pos := scheduler.Position(low); pos != 2 {
+		t.Errorf("low priority position want: 2 got: %v", pos)
+	}
+
+	scheduler.Cancel(low)
+	waitStatus(t, running, core.Running)
+	running.Kill()
+	waitStatus(t, high, core.Running)
+	high.Kill()
+}
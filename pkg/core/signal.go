@@ -0,0 +1,54 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+)
+
+// signals maps the names accepted by ParseSignal to signals
+var signals = map[string]syscall.Signal{
+	"SIGHUP":   syscall.SIGHUP,
+	"SIGINT":   syscall.SIGINT,
+	"SIGQUIT":  syscall.SIGQUIT,
+	"SIGILL":   syscall.SIGILL,
+	"SIGTRAP":  syscall.SIGTRAP,
+	"SIGABRT":  syscall.SIGABRT,
+	"SIGBUS":   syscall.SIGBUS,
+	"SIGFPE":   syscall.SIGFPE,
+	"SIGKILL":  syscall.SIGKILL,
+	"SIGUSR1":  syscall.SIGUSR1,
+	"SIGSEGV":  syscall.SIGSEGV,
+	"SIGUSR2":  syscall.SIGUSR2,
+	"SIGPIPE":  syscall.SIGPIPE,
+	"SIGALRM":  syscall.SIGALRM,
+	"SIGTERM":  syscall.SIGTERM,
+	"SIGCONT":  syscall.SIGCONT,
+	"SIGSTOP":  syscall.SIGSTOP,
+	"SIGTSTP":  syscall.SIGTSTP,
+	"SIGXCPU":  syscall.SIGXCPU,
+	"SIGWINCH": syscall.SIGWINCH,
+}
+
+// ParseSignal converts a signal name such as "SIGTERM" or "term" to a signal
+func ParseSignal(name string) (syscall.Signal, error) {
+	name = strings.ToUpper(name)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	sig, ok := signals[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown signal %q", name)
+	}
+	return sig, nil
+}
+
+// SignalName returns the name of a signal such as "SIGTERM"
+func SignalName(sig syscall.Signal) string {
+	for name, s := range signals {
+		if s == sig {
+			return name
+		}
+	}
+	return fmt.Sprintf("signal %d", int(sig))
+}
@@ -2,28 +2,104 @@ package core
 
 import "sync"
 
-// JobStore is an in memory storage interface for jobs
-type JobStore struct {
-	jobs map[string]*Job
-	mu   sync.RWMutex
+// JobStore provides storage for jobs
+type JobStore interface {
+	// Add adds a job to the store
+	Add(j *Job) error
+	// Get returns a job
+	Get(id string) (*Job, bool)
+	// Delete removes a job from the store
+	Delete(id string) error
+	// List returns every job in the store
+	List() []*Job
+	// ListClient returns the jobs of a client
+	ListClient(clientID string) []*Job
 }
 
-// NewJobStore creates a new empty job store
-func NewJobStore() *JobStore {
-	return &JobStore{jobs: make(map[string]*Job)}
+// clientIndex holds the jobs of each client
+type clientIndex map[string]map[string]*Job
+
+// add indexes a job under its client
+func (ci clientIndex) add(j *Job) {
+	jobs, ok := ci[j.ClientID]
+	if !ok {
+		jobs = make(map[string]*Job)
+		ci[j.ClientID] = jobs
+	}
+	jobs[j.ID] = j
+}
+
+// remove drops a job from the index
+func (ci clientIndex) remove(j *Job) {
+	delete(ci[j.ClientID], j.ID)
+	if len(ci[j.ClientID]) == 0 {
+		delete(ci, j.ClientID)
+	}
+}
+
+// list returns the jobs of a client
+func (ci clientIndex) list(clientID string) []*Job {
+	jobs := make([]*Job, 0, len(ci[clientID]))
+	for _, j := range ci[clientID] {
+		jobs = append(jobs, j)
+	}
+	return jobs
+}
+
+// MemoryStore is an in memory JobStore, jobs are lost when the server stops
+type MemoryStore struct {
+	jobs    map[string]*Job
+	clients clientIndex
+	mu      sync.RWMutex
+}
+
+// NewMemoryStore creates a new empty job store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{jobs: make(map[string]*Job), clients: make(clientIndex)}
 }
 
 // Add adds a job to the store
-func (js *JobStore) Add(j *Job) {
-	js.mu.Lock()
-	defer js.mu.Unlock()
-	js.jobs[j.ID] = j
+func (ms *MemoryStore) Add(j *Job) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.jobs[j.ID] = j
+	ms.clients.add(j)
+	return nil
 }
 
 // Get returns a job
-func (js *JobStore) Get(id string) (j *Job, ok bool) {
-	js.mu.RLock()
-	defer js.mu.RUnlock()
-	j, ok = js.jobs[id]
+func (ms *MemoryStore) Get(id string) (j *Job, ok bool) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	j, ok = ms.jobs[id]
 	return j, ok
 }
+
+// Delete removes a job from the store
+func (ms *MemoryStore) Delete(id string) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if j, ok := ms.jobs[id]; ok {
+		ms.clients.remove(j)
+		delete(ms.jobs, id)
+	}
+	return nil
+}
+
+// List returns every job in the store
+func (ms *MemoryStore) List() []*Job {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	jobs := make([]*Job, 0, len(ms.jobs))
+	for _, j := range ms.jobs {
+		jobs = append(jobs, j)
+	}
+	return jobs
+}
+
+// ListClient returns the jobs of a client
+func (ms *MemoryStore) ListClient(clientID string) []*Job {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.clients.list(clientID)
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// pausableTimer calls a function once it has been running for a duration,
+// time spent paused does not count towards the duration
+type pausableTimer struct {
+	fn        func()
+	remaining time.Duration
+	started   time.Time
+	timer     *time.Timer
+	mu        sync.Mutex
+}
+
+// newPausableTimer starts a timer which calls fn after d
+func newPausableTimer(d time.Duration, fn func()) *pausableTimer {
+	t := &pausableTimer{fn: fn, remaining: d}
+	t.Resume()
+	return t
+}
+
+// Pause stops the timer keeping the remaining duration
+func (t *pausableTimer) Pause() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.timer == nil {
+		return
+	}
+	if t.timer.Stop() {
+		t.remaining -= time.Since(t.started)
+	}
+	t.timer = nil
+}
+
+// Resume restarts a paused timer for the remaining duration
+func (t *pausableTimer) Resume() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.timer != nil {
+		return
+	}
+	t.started = time.Now()
+	t.timer = time.AfterFunc(t.remaining, t.fn)
+}
+
+// Stop stops the timer for good
+func (t *pausableTimer) Stop() {
+	t.Pause()
+}
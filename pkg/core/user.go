@@ -0,0 +1,55 @@
+package core
+
+import (
+	"os/user"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// LookupCredential resolves "user[:gid]" to the credential a job runs with,
+// user is either a username or a numeric uid
+func LookupCredential(name string) (*syscall.Credential, error) {
+	name, gidStr := name, ""
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		name, gidStr = name[:i], name[i+1:]
+	}
+
+	cred := &syscall.Credential{Groups: []uint32{}}
+	if uid, err := strconv.ParseUint(name, 10, 32); err == nil {
+		cred.Uid = uint32(uid)
+		cred.Gid = uint32(uid)
+	} else {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return nil, err
+		}
+		if cred.Uid, err = parseID(u.Uid); err != nil {
+			return nil, err
+		}
+		if cred.Gid, err = parseID(u.Gid); err != nil {
+			return nil, err
+		}
+		groups, _ := u.GroupIds()
+		for _, g := range groups {
+			if id, err := parseID(g); err == nil {
+				cred.Groups = append(cred.Groups, id)
+			}
+		}
+	}
+
+	if gidStr != "" {
+		gid, err := parseID(gidStr)
+		if err != nil {
+			return nil, err
+		}
+		cred.Gid = gid
+	}
+	return cred, nil
+}
+
+// parseID parses a numeric uid or gid
+func parseID(s string) (uint32, error) {
+	id, err := strconv.ParseUint(s, 10, 32)
+	return uint32(id), err
+}